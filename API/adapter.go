@@ -0,0 +1,150 @@
+package api
+
+import (
+	"fmt"
+	"go/ast"
+	"strconv"
+	"strings"
+)
+
+// AnyMethod is the method of route that accept every http method, eg. chi r.Handle("/item", h)
+const AnyMethod = "ANY"
+
+// AutoRouter is the router name used to detect adapter from the package imports
+const AutoRouter = "auto"
+
+// Adapter recognize how a web framework register route and sub router
+type Adapter interface {
+	// Name of the adapter, used as router in config, eg. chi
+	Name() string
+	// Imports is import path of the framework, used to detect the adapter
+	Imports() []string
+	// NewRouter will check if call create a new root router, eg. chi.NewRouter()
+	NewRouter(call *ast.CallExpr) bool
+	// Route will check if call register a route, eg. r.Get("/item", h.GetItem)
+	Route(call *ast.CallExpr, str StringFunc) (RouteCall, bool)
+	// Subrouter will check if call create or mount a sub router, eg. r.Route("/v1", fn)
+	Subrouter(call *ast.CallExpr, str StringFunc) (SubrouterCall, bool)
+}
+
+// StringFunc resolve string value of expr, eg. string literal or constant
+type StringFunc func(expr ast.Expr) (string, bool)
+
+// RouteCall is a route registration recognized by an Adapter
+type RouteCall struct {
+	Router  ast.Expr // router the route is registered on
+	Methods []string // http method in upper case, empty for every method
+	Path    string
+	Handler ast.Expr
+}
+
+// SubrouterCall is a sub router recognized by an Adapter
+type SubrouterCall struct {
+	Parent   ast.Expr // router the sub router is created from
+	Prefix   string   // prefix added to every route of the sub router, can be empty (eg. chi r.Group)
+	Callback ast.Expr // function that receive the sub router, eg. chi r.Route("/v1", func(r chi.Router) {...})
+	Mount    ast.Expr // existing router mounted under Prefix, eg. chi r.Mount("/admin", adminRouter())
+}
+
+// Adapters is every built in adapter, in the order used when more than one is detected
+var Adapters = []Adapter{chiAdapter{}, gorillaAdapter{}, ginAdapter{}, echoAdapter{}, netHttpAdapter{}}
+
+// AdapterByName return built in adapter called name, eg. gin
+func AdapterByName(name string) (Adapter, error) {
+	var names []string
+	for _, a := range Adapters {
+		if a.Name() == name {
+			return a, nil
+		}
+		names = append(names, a.Name())
+	}
+	return nil, fmt.Errorf("unknown router %q, expected %s or %s", name, AutoRouter, strings.Join(names, ", "))
+}
+
+// detectAdapters return adapter of every framework imported by files
+// net/http is only used when no other framework is imported, since every framework service import it too
+// chi is used when nothing is detected, eg. route registered on an interface declared in the package
+func detectAdapters(files []*ast.File) []Adapter {
+	imported := make(map[string]bool)
+	for _, file := range files {
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err == nil {
+				imported[path] = true
+			}
+		}
+	}
+
+	var found []Adapter
+	for _, a := range Adapters {
+		for _, prefix := range a.Imports() {
+			if hasImport(imported, prefix) {
+				found = append(found, a)
+				break
+			}
+		}
+	}
+	if len(found) > 1 && found[len(found)-1].Name() == "nethttp" {
+		found = found[:len(found)-1]
+	}
+	if len(found) == 0 {
+		found = []Adapter{chiAdapter{}}
+	}
+	return found
+}
+
+// hasImport will check if path or one of its major version (eg. path/v5) is imported
+func hasImport(imported map[string]bool, path string) bool {
+	for i := range imported {
+		if i == path || strings.HasPrefix(i, path+"/v") {
+			return true
+		}
+	}
+	return false
+}
+
+// isPackageCall will check if call is pkg.name(), eg. chi.NewRouter()
+func isPackageCall(call *ast.CallExpr, pkg string, names ...string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok || ident.Name != pkg {
+		return false
+	}
+	for _, name := range names {
+		if sel.Sel.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// methodCall return receiver and name of method call, eg. r.Get(...) -> r, Get
+func methodCall(call *ast.CallExpr) (ast.Expr, string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, "", false
+	}
+	return sel.X, sel.Sel.Name, true
+}
+
+// routePath will resolve expr as route path, path must start with /
+// eg. cache.Get("key", &value) is not a route
+func routePath(expr ast.Expr, str StringFunc) (string, bool) {
+	path, ok := str(expr)
+	if !ok || !strings.HasPrefix(path, "/") {
+		return "", false
+	}
+	return path, true
+}
+
+// methodValue will resolve expr as http method, eg. "GET" or http.MethodGet
+func methodValue(expr ast.Expr, str StringFunc) (string, bool) {
+	if sel, ok := expr.(*ast.SelectorExpr); ok && strings.HasPrefix(sel.Sel.Name, "Method") {
+		return strings.ToUpper(strings.TrimPrefix(sel.Sel.Name, "Method")), true
+	}
+	method, ok := str(expr)
+	return strings.ToUpper(method), ok
+}
@@ -0,0 +1,330 @@
+package api
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// chi method that register a route, eg. r.Get("/item", h.GetItem)
+var chiMethods = map[string]string{
+	"Get":     "GET",
+	"Post":    "POST",
+	"Put":     "PUT",
+	"Patch":   "PATCH",
+	"Delete":  "DELETE",
+	"Head":    "HEAD",
+	"Options": "OPTIONS",
+	"Connect": "CONNECT",
+	"Trace":   "TRACE",
+}
+
+// gin and echo method that register a route, eg. r.GET("/item", h.GetItem)
+var upperMethods = map[string]bool{
+	"GET":     true,
+	"POST":    true,
+	"PUT":     true,
+	"PATCH":   true,
+	"DELETE":  true,
+	"HEAD":    true,
+	"OPTIONS": true,
+	"CONNECT": true,
+	"TRACE":   true,
+}
+
+// chiAdapter recognize github.com/go-chi/chi
+type chiAdapter struct{}
+
+func (chiAdapter) Name() string { return "chi" }
+
+func (chiAdapter) Imports() []string {
+	return []string{"github.com/go-chi/chi", "github.com/pressly/chi"}
+}
+
+func (chiAdapter) NewRouter(call *ast.CallExpr) bool {
+	return isPackageCall(call, "chi", "NewRouter", "NewMux")
+}
+
+func (chiAdapter) Route(call *ast.CallExpr, str StringFunc) (RouteCall, bool) {
+	recv, name, ok := methodCall(call)
+	if !ok || len(call.Args) < 2 {
+		return RouteCall{}, false
+	}
+	args := call.Args
+	var methods []string
+	switch name {
+	case "Handle", "HandleFunc": // every method
+	case "Method", "MethodFunc": // r.Method("GET", "/item", h)
+		if len(args) < 3 {
+			return RouteCall{}, false
+		}
+		method, ok := methodValue(args[0], str)
+		if !ok {
+			return RouteCall{}, false
+		}
+		methods, args = []string{method}, args[1:]
+	default:
+		method, ok := chiMethods[name]
+		if !ok {
+			return RouteCall{}, false
+		}
+		methods = []string{method}
+	}
+	path, ok := routePath(args[0], str)
+	if !ok {
+		return RouteCall{}, false
+	}
+	return RouteCall{Router: recv, Methods: methods, Path: path, Handler: args[len(args)-1]}, true
+}
+
+func (chiAdapter) Subrouter(call *ast.CallExpr, str StringFunc) (SubrouterCall, bool) {
+	recv, name, ok := methodCall(call)
+	if !ok {
+		return SubrouterCall{}, false
+	}
+	switch name {
+	case "Route": // r.Route("/v1", func(r chi.Router) { ... })
+		if len(call.Args) != 2 {
+			return SubrouterCall{}, false
+		}
+		prefix, ok := str(call.Args[0])
+		return SubrouterCall{Parent: recv, Prefix: prefix, Callback: call.Args[1]}, ok
+	case "Group": // r.Group(func(r chi.Router) { ... }), share the prefix of r
+		if len(call.Args) != 1 {
+			return SubrouterCall{}, false
+		}
+		return SubrouterCall{Parent: recv, Callback: call.Args[0]}, true
+	case "Mount": // r.Mount("/admin", adminRouter())
+		if len(call.Args) != 2 {
+			return SubrouterCall{}, false
+		}
+		prefix, ok := str(call.Args[0])
+		return SubrouterCall{Parent: recv, Prefix: prefix, Mount: call.Args[1]}, ok
+	case "With": // r.With(middleware).Get("/item", h.GetItem), share the prefix of r
+		return SubrouterCall{Parent: recv}, true
+	}
+	return SubrouterCall{}, false
+}
+
+// gorillaAdapter recognize github.com/gorilla/mux
+type gorillaAdapter struct{}
+
+func (gorillaAdapter) Name() string { return "gorilla" }
+
+func (gorillaAdapter) Imports() []string { return []string{"github.com/gorilla/mux"} }
+
+func (gorillaAdapter) NewRouter(call *ast.CallExpr) bool {
+	return isPackageCall(call, "mux", "NewRouter")
+}
+
+// Route walk the whole method chain of a route
+// eg. r.HandleFunc("/item", h).Methods("GET").Name("item") or r.Path("/item").Methods("GET").HandlerFunc(h)
+// r.PathPrefix("/static").Handler(h) is a catch all route, eg. /static/*
+func (gorillaAdapter) Route(call *ast.CallExpr, str StringFunc) (RouteCall, bool) {
+	var route RouteCall
+	hasPath := false
+	expr := ast.Expr(call)
+chain:
+	for {
+		c, ok := expr.(*ast.CallExpr)
+		if !ok {
+			break
+		}
+		recv, name, ok := methodCall(c)
+		if !ok {
+			break
+		}
+		switch name {
+		case "HandleFunc", "Handle":
+			if len(c.Args) != 2 {
+				return RouteCall{}, false
+			}
+			route.Path, hasPath = routePath(c.Args[0], str)
+			route.Handler = c.Args[1]
+		case "Path":
+			if len(c.Args) != 1 {
+				return RouteCall{}, false
+			}
+			route.Path, hasPath = routePath(c.Args[0], str)
+		case "PathPrefix":
+			if len(c.Args) != 1 {
+				return RouteCall{}, false
+			}
+			if prefix, ok := routePath(c.Args[0], str); ok {
+				route.Path, hasPath = joinPath(prefix, "*"), true
+			}
+		case "HandlerFunc", "Handler":
+			if len(c.Args) != 1 {
+				return RouteCall{}, false
+			}
+			route.Handler = c.Args[0]
+		case "Methods":
+			for _, arg := range c.Args {
+				if method, ok := methodValue(arg, str); ok {
+					route.Methods = append(route.Methods, method)
+				}
+			}
+		case "Name", "Schemes", "Headers", "HeadersRegexp", "Queries", "Host", "MatcherFunc":
+		default:
+			break chain
+		}
+		expr = recv
+	}
+	if !hasPath || route.Handler == nil {
+		return RouteCall{}, false
+	}
+	route.Router = expr
+	return route, true
+}
+
+func (gorillaAdapter) Subrouter(call *ast.CallExpr, str StringFunc) (SubrouterCall, bool) {
+	recv, name, ok := methodCall(call)
+	if !ok {
+		return SubrouterCall{}, false
+	}
+	switch name {
+	case "PathPrefix": // s := r.PathPrefix("/v1").Subrouter()
+		if len(call.Args) != 1 {
+			return SubrouterCall{}, false
+		}
+		prefix, ok := str(call.Args[0])
+		return SubrouterCall{Parent: recv, Prefix: prefix}, ok
+	case "Subrouter":
+		return SubrouterCall{Parent: recv}, true
+	}
+	return SubrouterCall{}, false
+}
+
+// ginAdapter recognize github.com/gin-gonic/gin
+type ginAdapter struct{}
+
+func (ginAdapter) Name() string { return "gin" }
+
+func (ginAdapter) Imports() []string { return []string{"github.com/gin-gonic/gin"} }
+
+func (ginAdapter) NewRouter(call *ast.CallExpr) bool {
+	return isPackageCall(call, "gin", "New", "Default")
+}
+
+// Route recognize r.GET("/item", middleware, h.GetItem), the last handler is the endpoint handler
+func (ginAdapter) Route(call *ast.CallExpr, str StringFunc) (RouteCall, bool) {
+	return upperRoute(call, str, false)
+}
+
+func (ginAdapter) Subrouter(call *ast.CallExpr, str StringFunc) (SubrouterCall, bool) {
+	return groupSubrouter(call, str)
+}
+
+// echoAdapter recognize github.com/labstack/echo
+type echoAdapter struct{}
+
+func (echoAdapter) Name() string { return "echo" }
+
+func (echoAdapter) Imports() []string { return []string{"github.com/labstack/echo"} }
+
+func (echoAdapter) NewRouter(call *ast.CallExpr) bool {
+	return isPackageCall(call, "echo", "New")
+}
+
+// Route recognize e.GET("/item", h.GetItem, middleware), the handler is right after the path
+func (echoAdapter) Route(call *ast.CallExpr, str StringFunc) (RouteCall, bool) {
+	return upperRoute(call, str, true)
+}
+
+func (echoAdapter) Subrouter(call *ast.CallExpr, str StringFunc) (SubrouterCall, bool) {
+	return groupSubrouter(call, str)
+}
+
+// upperRoute recognize gin and echo route, eg. r.GET("/item", h), r.Any("/item", h),
+// r.Handle("GET", "/item", h) (gin), e.Add("GET", "/item", h) and e.Match([]string{"GET"}, "/item", h) (echo)
+// handlerFirst is true if the handler is right after the path, otherwise it is the last argument
+func upperRoute(call *ast.CallExpr, str StringFunc, handlerFirst bool) (RouteCall, bool) {
+	recv, name, ok := methodCall(call)
+	if !ok || len(call.Args) < 2 {
+		return RouteCall{}, false
+	}
+	args := call.Args
+	var methods []string
+	switch {
+	case upperMethods[name]:
+		methods = []string{name}
+	case name == "Any":
+	case name == "Handle" || name == "Add":
+		method, ok := methodValue(args[0], str)
+		if !ok || len(args) < 3 {
+			return RouteCall{}, false
+		}
+		methods, args = []string{method}, args[1:]
+	case name == "Match":
+		lit, ok := args[0].(*ast.CompositeLit)
+		if !ok || len(args) < 3 {
+			return RouteCall{}, false
+		}
+		for _, elt := range lit.Elts {
+			if method, ok := methodValue(elt, str); ok {
+				methods = append(methods, method)
+			}
+		}
+		args = args[1:]
+	default:
+		return RouteCall{}, false
+	}
+	path, ok := routePath(args[0], str)
+	if !ok {
+		return RouteCall{}, false
+	}
+	handler := args[len(args)-1]
+	if handlerFirst {
+		handler = args[1]
+	}
+	return RouteCall{Router: recv, Methods: methods, Path: path, Handler: handler}, true
+}
+
+// groupSubrouter recognize gin and echo group, eg. v1 := r.Group("/v1", middleware)
+func groupSubrouter(call *ast.CallExpr, str StringFunc) (SubrouterCall, bool) {
+	recv, name, ok := methodCall(call)
+	if !ok || name != "Group" || len(call.Args) == 0 {
+		return SubrouterCall{}, false
+	}
+	prefix, ok := str(call.Args[0])
+	return SubrouterCall{Parent: recv, Prefix: prefix}, ok
+}
+
+// netHttpAdapter recognize net/http ServeMux, including go 1.22 pattern with method, eg. "GET /item/{id}"
+type netHttpAdapter struct{}
+
+func (netHttpAdapter) Name() string { return "nethttp" }
+
+func (netHttpAdapter) Imports() []string { return []string{"net/http"} }
+
+func (netHttpAdapter) NewRouter(call *ast.CallExpr) bool {
+	return isPackageCall(call, "http", "NewServeMux")
+}
+
+// Route recognize mux.HandleFunc("GET /item/{id}", h) and http.Handle("/item", h)
+// route registered with http.HandleFunc is registered on the http.DefaultServeMux
+func (netHttpAdapter) Route(call *ast.CallExpr, str StringFunc) (RouteCall, bool) {
+	recv, name, ok := methodCall(call)
+	if !ok || (name != "HandleFunc" && name != "Handle") || len(call.Args) != 2 {
+		return RouteCall{}, false
+	}
+	pattern, ok := str(call.Args[0])
+	if !ok {
+		return RouteCall{}, false
+	}
+
+	// pattern is [METHOD ][HOST]/[PATH]
+	var methods []string
+	if i := strings.IndexAny(pattern, " \t"); i != -1 {
+		methods = []string{strings.ToUpper(pattern[:i])}
+		pattern = strings.TrimLeft(pattern[i:], " \t")
+	}
+	i := strings.IndexByte(pattern, '/')
+	if i == -1 {
+		return RouteCall{}, false
+	}
+	return RouteCall{Router: recv, Methods: methods, Path: pattern[i:], Handler: call.Args[1]}, true
+}
+
+func (netHttpAdapter) Subrouter(call *ast.CallExpr, str StringFunc) (SubrouterCall, bool) {
+	return SubrouterCall{}, false
+}
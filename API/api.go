@@ -0,0 +1,228 @@
+// Package api lists REST endpoint of a service and its integration test into ITSWEEP sheet
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+
+	"github.com/IndraWirananta/IntegrationTestRelatedScript/testcase"
+)
+
+// Config hold every path needed by api sweep
+type Config struct {
+	IntegrationPath string   `yaml:"tests"`      // integration test local path
+	ApplicationPath string   `yaml:"routes"`     // app package directory or one of its file (eg. http.go)
+	OpenAPIPath     string   `yaml:"openapi"`    // OpenAPI 3 spec (yaml or json), used with or instead of ApplicationPath
+	Router          string   `yaml:"router"`     // adapter name, eg. chi, empty or auto to detect it from imports
+	DocumentName    string   `yaml:"output"`     // file name for sheet
+	Envs            []string `yaml:"envs"`       // env to report, the first env a test has is used
+	Status          []string `yaml:"status"`     // dropdown value of status column
+	IgnoreKeys      []string `yaml:"ignoreKeys"` // apiParamMap keys that are not route param
+	Gateway         []Route  `yaml:"-"`          // route exposed by grpc-gateway, see grpc.GatewayRoutes
+}
+
+// Run will list every endpoint in ApplicationPath package along with its integration test
+// and save it as excel sheet in DocumentName
+func Run(cfg Config) error {
+	integrationPath := cfg.IntegrationPath
+	applicationPath := cfg.ApplicationPath
+	documentName := cfg.DocumentName
+
+	// create new excel sheet
+	xlsx := excelize.NewFile()
+	sheet1Name := "Sheet1"
+	xlsx.SetSheetName(xlsx.GetSheetName(1), sheet1Name)
+
+	// create column name
+	xlsx.SetCellValue(sheet1Name, "A1", "Endpoint")
+	xlsx.SetCellValue(sheet1Name, "B1", "Type")
+	xlsx.SetCellValue(sheet1Name, "C1", "Test Case Name")
+	xlsx.SetCellValue(sheet1Name, "D1", "File Name")
+	xlsx.SetCellValue(sheet1Name, "E1", "Scenario")
+	xlsx.SetCellValue(sheet1Name, "F1", "Expected Response")
+	xlsx.SetCellValue(sheet1Name, "G1", "Request Param")
+	xlsx.SetCellValue(sheet1Name, "H1", "Status")
+	xlsx.SetCellValue(sheet1Name, "I1", "Notes")
+	xlsx.SetCellValue(sheet1Name, "J1", "PIC")
+
+	// add auto filter to column
+	err := xlsx.AutoFilter(sheet1Name, "A1", "J1", "")
+	if err != nil {
+		return err
+	}
+
+	// add data validation for status column
+	dvRange := excelize.NewDataValidation(true)
+	dvRange.Sqref = "H:H"
+	dvRange.SetDropList(cfg.Status)
+	xlsx.AddDataValidation(sheet1Name, dvRange)
+
+	// find every route registered in app package
+	var adapters []Adapter
+	if cfg.Router != "" && cfg.Router != AutoRouter {
+		adapter, err := AdapterByName(cfg.Router)
+		if err != nil {
+			return err
+		}
+		adapters = append(adapters, adapter)
+	}
+	var routes []Route
+	if applicationPath != "" {
+		routes, err = DiscoverRoutes(applicationPath, adapters...)
+		if err != nil {
+			return err
+		}
+	}
+
+	// operation in OpenAPI spec replace route found in app source, since it has operationId, tags and deprecated
+	if cfg.OpenAPIPath != "" {
+		operations, err := LoadOpenAPI(cfg.OpenAPIPath)
+		if err != nil {
+			return err
+		}
+		routes = append(routes, operations...)
+	}
+	mapApiList := make(map[string]Route) // every route keyed by path template and method
+	for _, route := range routes {
+		mapApiList[route.Key()] = route
+	}
+
+	// route of google.api.http annotation is linked with route found in app source, or added if the app doesnt register it
+	for _, route := range cfg.Gateway {
+		if existing, ok := mapApiList[route.Key()]; ok {
+			existing.RPC = route.RPC
+			existing.RPCTests = route.RPCTests
+			route = existing
+		}
+		mapApiList[route.Key()] = route
+	}
+	tested := make(map[string]bool) // route that has integration test
+
+	var total int // total integration test
+
+	err = testcase.Walk(integrationPath, // will "walk" to every directory and subdirectory in integrationPath
+		func(path string, result *testcase.IntegrationTest, err error) error {
+			if err != nil {
+				fmt.Println(err)
+				return nil
+			}
+
+			// only report the first env in cfg.Envs that the test has
+			structure, ok := result.First(cfg.Envs)
+			if !ok {
+				fmt.Printf("skip %s: no structure for env %v\n", path, cfg.Envs)
+				return nil
+			}
+			total++
+
+			variables := structure.VariablesString()
+
+			// eg. {host}/remind/add -> /remind/add
+			apiName := TrimHost(result.ApiName)
+			httpMethod := strings.ToUpper(result.HttpMethod)
+
+			// mark endpoint that has integration test
+			key, hasRoute := FindRoute(mapApiList, result.ApiName, httpMethod)
+			if hasRoute {
+				tested[key] = true
+			}
+
+			// transform route param into variables
+			routeParams, err := structure.RouteParams(cfg.IgnoreKeys)
+			if err != nil {
+				fmt.Println(path, err)
+			}
+			if len(routeParams) > 0 {
+				routeVariable, _ := json.Marshal(routeParams)
+
+				// remove route param from apiName
+				if strings.IndexByte(apiName, '?') != -1 {
+					apiName = apiName[:strings.IndexByte(apiName, '?')]
+				}
+				apiName = testcase.FillRoute(apiName, routeParams)
+				variables = string(routeVariable)
+			}
+
+			// insert data to sheet
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("A%d", total+1), apiName)
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("B%d", total+1), strings.ToUpper(result.HttpMethod))
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("C%d", total+1), result.QueryName)
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("D%d", total+1), result.FileName())
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("F%d", total+1), structure.ResponseCode)
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("G%d", total+1), variables)
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("H%d", total+1), "Live")
+			var notes []string
+			if hasRoute && mapApiList[key].Deprecated {
+				notes = append(notes, "Deprecated")
+			}
+			if hasRoute && mapApiList[key].RPC != "" {
+				notes = append(notes, "gRPC "+mapApiList[key].RPC)
+			}
+			if len(notes) > 0 {
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("I%d", total+1), strings.Join(notes, ", "))
+			}
+			return nil
+		})
+	if err != nil {
+		log.Println(err)
+	}
+
+	// used to sort mapApiList
+	keys := make([]string, 0, len(mapApiList))
+	for k := range mapApiList {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Println("Got a total of " + strconv.Itoa(total) + " testcases")
+
+	// insert endpoint that doesnt has integration test
+	for _, k := range keys {
+		if !tested[k] {
+			route := mapApiList[k]
+			total++
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("A%d", total+1), route.Path)
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("B%d", total+1), route.Method)
+			if strings.Contains(route.Path, "intools") {
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("H%d", total+1), "Wont Do")
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("I%d", total+1), "Intools")
+			} else if len(route.RPCTests) > 0 {
+				// grpc-gateway route without REST test, but its rpc is tested through grpc
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("H%d", total+1), "Live")
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("I%d", total+1), "Covered by gRPC test "+strings.Join(route.RPCTests, ", ")+" of "+route.RPC)
+			} else if route.Deprecated {
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("H%d", total+1), "Wont Do")
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("I%d", total+1), "Deprecated, "+routeNote(route))
+			} else {
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("H%d", total+1), "No TestCase")
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("I%d", total+1), routeNote(route))
+			}
+		}
+	}
+
+	fmt.Println("Scanned a total of " + strconv.Itoa(len(mapApiList)) + " endpoint")
+
+	// save created sheet
+	return xlsx.SaveAs(documentName)
+}
+
+// routeNote will describe where route is registered, eg. h.GetItem at http.go:12
+// OpenAPI operation also note its tags, eg. getItem at openapi.yaml:20 [item]
+// route linked with grpc-gateway also note its rpc, eg. h.GetItem at http.go:12, gRPC sampleapp.Sampleapp/GetItem
+func routeNote(route Route) string {
+	note := strings.TrimSpace(fmt.Sprintf("%s at %s:%d", route.Handler, filepath.Base(route.Pos.Filename), route.Pos.Line))
+	if len(route.Tags) > 0 {
+		note += " [" + strings.Join(route.Tags, ", ") + "]"
+	}
+	if route.RPC != "" && route.RPC != route.Handler {
+		note += ", gRPC " + route.RPC
+	}
+	return note
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// operation method of an OpenAPI path item
+var openAPIMethods = map[string]bool{
+	"get":     true,
+	"put":     true,
+	"post":    true,
+	"delete":  true,
+	"options": true,
+	"head":    true,
+	"patch":   true,
+	"trace":   true,
+}
+
+// openAPIOperation is the part of an OpenAPI operation shown in the sheet
+type openAPIOperation struct {
+	OperationID string   `yaml:"operationId"`
+	Summary     string   `yaml:"summary"`
+	Tags        []string `yaml:"tags"`
+	Deprecated  bool     `yaml:"deprecated"`
+}
+
+// maxRefDepth is how many $ref is followed from a path item, used to stop recursive $ref
+const maxRefDepth = 16
+
+// LoadOpenAPI will return every operation in OpenAPI 3.0 or 3.1 spec (yaml or json) in path as route
+// path of the first server is added as prefix, eg. servers: [{url: https://example.com/v1}]
+// path item with $ref is resolved from the same document or another file, $ref that cant be resolved is reported and skipped
+func LoadOpenAPI(path string) ([]Route, error) {
+	root, err := loadYAML(path)
+	if err != nil {
+		return nil, err
+	}
+
+	version := mappingValue(root, "openapi")
+	if version == nil || !strings.HasPrefix(version.Value, "3.") {
+		return nil, &os.PathError{Op: "parse", Path: path, Err: fmt.Errorf("only OpenAPI 3.0 and 3.1 is supported")}
+	}
+
+	prefix := ""
+	if servers := mappingValue(root, "servers"); servers != nil && servers.Kind == yaml.SequenceNode && len(servers.Content) > 0 {
+		if prefix, err = serverPath(servers.Content[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v, server path is not added to route\n", path, err)
+		}
+	}
+
+	var routes []Route
+	paths := mappingValue(root, "paths")
+	if paths == nil || paths.Kind != yaml.MappingNode {
+		return routes, nil
+	}
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		routePath := paths.Content[i].Value
+		item, file, err := resolvePathItem(paths.Content[i+1], root, path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: skip path %s, %v\n", path, routePath, err)
+			continue
+		}
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			method, node := item.Content[j].Value, item.Content[j+1]
+			if !openAPIMethods[method] {
+				continue // path item field, eg. parameters or summary
+			}
+			var op openAPIOperation
+			if err := node.Decode(&op); err != nil {
+				return nil, &os.PathError{Op: "parse", Path: file, Err: fmt.Errorf("%s %s: %v", method, routePath, err)}
+			}
+			handler := op.OperationID
+			if handler == "" {
+				handler = op.Summary
+			}
+			routes = append(routes, Route{
+				Method:      strings.ToUpper(method),
+				Path:        joinPath(prefix, routePath),
+				Handler:     handler,
+				Pos:         token.Position{Filename: file, Line: item.Content[j].Line, Column: item.Content[j].Column},
+				OperationID: op.OperationID,
+				Summary:     op.Summary,
+				Tags:        op.Tags,
+				Deprecated:  op.Deprecated,
+			})
+		}
+	}
+	return routes, nil
+}
+
+// loadYAML will parse yaml or json file in path and return its root mapping node
+// decoded as node to keep the line of every operation
+func loadYAML(path string) (*yaml.Node, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, &os.PathError{Op: "parse", Path: path, Err: err}
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, &os.PathError{Op: "parse", Path: path, Err: fmt.Errorf("not an OpenAPI document")}
+	}
+	return doc.Content[0], nil
+}
+
+// resolvePathItem will follow $ref of path item and return the path item with the file it is declared in
+// eg. $ref: '#/components/pathItems/Item', $ref: ./paths/item.yaml or $ref: ./paths.yaml#/Item
+// file is resolved against the file of the $ref, remote url is not supported
+func resolvePathItem(item, root *yaml.Node, path string) (*yaml.Node, string, error) {
+	for depth := 0; ; depth++ {
+		ref := mappingValue(item, "$ref")
+		if item.Kind != yaml.MappingNode || ref == nil {
+			return item, path, nil
+		}
+		if depth == maxRefDepth {
+			return nil, "", fmt.Errorf("$ref %s is nested too deep", ref.Value)
+		}
+
+		file, pointer := ref.Value, ""
+		if i := strings.Index(file, "#"); i >= 0 {
+			file, pointer = file[:i], file[i+1:]
+		}
+		if file != "" {
+			if strings.Contains(file, "://") {
+				return nil, "", fmt.Errorf("remote $ref %s is not supported", ref.Value)
+			}
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(filepath.Dir(path), file)
+			}
+			var err error
+			if root, err = loadYAML(file); err != nil {
+				return nil, "", err
+			}
+			path = file
+		}
+
+		item = jsonPointer(root, pointer)
+		if item == nil {
+			return nil, "", fmt.Errorf("$ref %s not found", ref.Value)
+		}
+	}
+}
+
+// jsonPointer return node of pointer inside root, eg. /components/pathItems/Item, nil if not found
+// empty pointer is root itself
+func jsonPointer(root *yaml.Node, pointer string) *yaml.Node {
+	if unescaped, err := url.PathUnescape(pointer); err == nil {
+		pointer = unescaped
+	}
+	node := root
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if pointer == "" || node == nil {
+			break
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node.Kind {
+		case yaml.MappingNode:
+			node = mappingValue(node, token)
+		case yaml.SequenceNode:
+			var index int
+			if _, err := fmt.Sscan(token, &index); err != nil || index < 0 || index >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[index]
+		default:
+			return nil
+		}
+	}
+	return node
+}
+
+// mappingValue return value of key in yaml mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// serverPath return path of OpenAPI server url, eg. https://example.com/v1 -> /v1
+// variable is replaced by its default, eg. https://{env}.example.com/{basePath} with basePath default v1 -> /v1
+// variable without default is only an error when it is in the path
+func serverPath(server *yaml.Node) (string, error) {
+	value := mappingValue(server, "url")
+	if value == nil {
+		return "", nil
+	}
+	raw := value.Value
+	if variables := mappingValue(server, "variables"); variables != nil && variables.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(variables.Content); i += 2 {
+			if def := mappingValue(variables.Content[i+1], "default"); def != nil {
+				raw = strings.Replace(raw, "{"+variables.Content[i].Value+"}", def.Value, -1)
+			}
+		}
+	}
+
+	// drop scheme and host, host can still have a variable, eg. https://{region}.example.com
+	if i := strings.Index(raw, "//"); i == 0 || (i > 0 && raw[i-1] == ':') {
+		raw = raw[i+2:]
+		j := strings.Index(raw, "/")
+		if j < 0 {
+			return "", nil
+		}
+		raw = raw[j:]
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid server url %s: %v", value.Value, err)
+	}
+	if strings.Contains(u.Path, "{") {
+		return "", fmt.Errorf("server url %s has a variable without default", value.Value)
+	}
+	return strings.TrimSuffix(u.Path, "/"), nil
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// writeFiles will write every file in a temp directory and return the directory
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadOpenAPI(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"openapi.yaml": `openapi: 3.1.0
+servers:
+  - url: https://example.com/v1/
+paths:
+  /item/{id}:
+    parameters:
+      - name: id
+        in: path
+    get:
+      operationId: getItem
+      tags: [item]
+    delete:
+      summary: delete item
+      deprecated: true
+  /cart:
+    $ref: '#/components/pathItems/Cart'
+  /order:
+    $ref: ./paths.yaml#/Order
+  /user:
+    $ref: ./user.yaml
+  /missing:
+    $ref: '#/components/pathItems/Missing'
+  /remote:
+    $ref: https://example.com/paths.yaml#/Remote
+components:
+  pathItems:
+    Cart:
+      $ref: '#/components/pathItems/CartItem'
+    CartItem:
+      post:
+        operationId: addCart
+`,
+		"paths.yaml": `Order:
+  get:
+    operationId: listOrder
+`,
+		"user.yaml": `put:
+  operationId: updateUser
+`,
+	})
+
+	routes, err := LoadOpenAPI(filepath.Join(dir, "openapi.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range routes {
+		got = append(got, r.Method+" "+r.Path+" "+r.Handler+" "+filepath.Base(r.Pos.Filename))
+	}
+	// $ref that cant be resolved is skipped
+	want := []string{
+		"GET /v1/item/{id} getItem openapi.yaml",
+		"DELETE /v1/item/{id} delete item openapi.yaml",
+		"POST /v1/cart addCart openapi.yaml",
+		"GET /v1/order listOrder paths.yaml",
+		"PUT /v1/user updateUser user.yaml",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("routes\ngot  %q\nwant %q", got, want)
+	}
+
+	if r := routes[0]; r.Pos.Line != 9 || r.OperationID != "getItem" || !reflect.DeepEqual(r.Tags, []string{"item"}) {
+		t.Errorf("GET /v1/item/{id} = %+v", r)
+	}
+	if r := routes[1]; !r.Deprecated || r.Summary != "delete item" {
+		t.Errorf("DELETE /v1/item/{id} = %+v", r)
+	}
+}
+
+func TestLoadOpenAPIInvalid(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"swagger.yaml": "swagger: \"2.0\"\npaths: {}\n",
+		"list.yaml":    "- openapi\n",
+	})
+	for _, name := range []string{"swagger.yaml", "list.yaml", "missing.yaml"} {
+		if _, err := LoadOpenAPI(filepath.Join(dir, name)); err == nil {
+			t.Errorf("LoadOpenAPI(%s) should fail", name)
+		}
+	}
+}
+
+func TestServerPath(t *testing.T) {
+	tests := []struct {
+		server  string
+		want    string
+		wantErr bool
+	}{
+		{"url: https://example.com/v1/", "/v1", false},
+		{"url: https://example.com", "", false},
+		{"url: /api", "/api", false},
+		{"url: //example.com/api", "/api", false},
+		{"{url: 'https://{env}.example.com/v1'}", "/v1", false}, // variable in host without default
+		{"{url: 'https://example.com/{basePath}', variables: {basePath: {default: v2}}}", "/v2", false},
+		{"{url: '{scheme}://example.com/{version}/api', variables: {scheme: {default: https}, version: {default: v3}}}", "/v3/api", false},
+		{"{url: 'https://example.com/{basePath}'}", "", true},
+	}
+	for _, tt := range tests {
+		var server yaml.Node
+		if err := yaml.Unmarshal([]byte(tt.server), &server); err != nil {
+			t.Fatal(err)
+		}
+		got, err := serverPath(server.Content[0])
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("serverPath(%s) = %q, %v, want %q, error %v", tt.server, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// used to find route param inside a path segment, eg. {id} or {id:[0-9]+} in item-{id}
+var paramRegex = regexp.MustCompile(`\{[^{}/]*\}`)
+
+// PathTemplate return the canonical form of a route or apiName path, used to match route with integration test
+// every route param is {} and every catch all param is *, query string and trailing slash is removed
+// eg. /item/{id}, /item/:id and /item/{itemId}?x=1 -> /item/{}, /static/*filepath and /static/{path...} -> /static/*
+func PathTemplate(path string) string {
+	if i := strings.IndexAny(path, "?#"); i != -1 {
+		path = path[:i]
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		switch {
+		case segment == "" || segment == "{$}": // empty or go 1.22 end of path marker
+			continue
+		case strings.HasPrefix(segment, ":"): // gin and echo param
+			segment = "{}"
+		case strings.HasPrefix(segment, "*"): // gin and chi catch all
+			segment = "*"
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "...}"): // go 1.22 catch all
+			segment = "*"
+		case strings.HasPrefix(segment, "{") && (strings.HasSuffix(segment, ":.*}") || strings.HasSuffix(segment, ":.+}")): // gorilla catch all
+			segment = "*"
+		default:
+			segment = paramRegex.ReplaceAllString(segment, "{}")
+		}
+		segments = append(segments, segment)
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// TrimHost will remove host from apiName, eg. {host}/item or http://localhost:9000/item -> /item
+func TrimHost(apiName string) string {
+	if i := strings.Index(apiName, "://"); i != -1 {
+		apiName = apiName[i+len("://"):]
+		if j := strings.IndexByte(apiName, '/'); j != -1 {
+			return apiName[j:]
+		}
+		return "/"
+	}
+	if strings.HasPrefix(apiName, "{") {
+		if i := strings.IndexByte(apiName, '}'); i != -1 && !strings.HasPrefix(apiName[i+1:], "{") {
+			return apiName[i+1:]
+		}
+	}
+	return apiName
+}
+
+// matchTemplate will check if test path template match route path template
+// route {} match any segment and route * match the rest of the path
+// return number of literal segment matched, used to choose the most specific route
+func matchTemplate(route, test string) (int, bool) {
+	routeSegments := strings.Split(strings.TrimPrefix(route, "/"), "/")
+	testSegments := strings.Split(strings.TrimPrefix(test, "/"), "/")
+	literal := 0
+	for i, segment := range routeSegments {
+		if segment == "*" {
+			return literal, true
+		}
+		if i >= len(testSegments) {
+			return 0, false
+		}
+		switch {
+		case segment == testSegments[i]:
+			literal++
+		case segment == "{}" && testSegments[i] != "":
+		default:
+			return 0, false
+		}
+	}
+	return literal, len(routeSegments) == len(testSegments)
+}
+
+// FindRoute return key of route in routes tested by apiName and method
+// exact template is used first, otherwise the most specific route whose template match apiName
+// route that accept every method is tested by test of any method
+func FindRoute(routes map[string]Route, apiName, method string) (string, bool) {
+	template := PathTemplate(TrimHost(apiName))
+	for _, key := range []string{template + method, template + AnyMethod} {
+		if _, ok := routes[key]; ok {
+			return key, true
+		}
+	}
+
+	keys := make([]string, 0, len(routes))
+	for k := range routes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	found, best := "", -1
+	for _, k := range keys {
+		route := routes[k]
+		if route.Method != method && route.Method != AnyMethod {
+			continue
+		}
+		if literal, ok := matchTemplate(PathTemplate(route.Path), template); ok && literal > best {
+			found, best = k, literal
+		}
+	}
+	return found, best != -1
+}
@@ -0,0 +1,116 @@
+package api
+
+import "testing"
+
+func TestPathTemplate(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/item/{id}", "/item/{}"},
+		{"/item/:id", "/item/{}"},
+		{"/item/{id:[0-9]+}", "/item/{}"},
+		{"/item/{itemId}?x=1", "/item/{}"},
+		{"/item/{id}#top", "/item/{}"},
+		{"/item/{id}/", "/item/{}"},
+		{"/item-{id}.json", "/item-{}.json"},
+		{"/static/*filepath", "/static/*"},
+		{"/static/*", "/static/*"},
+		{"/files/{path...}", "/files/*"},
+		{"/files/{path:.*}", "/files/*"},
+		{"/{$}", "/"},
+		{"", "/"},
+		{"//item//list", "/item/list"},
+	}
+	for _, tt := range tests {
+		if got := PathTemplate(tt.path); got != tt.want {
+			t.Errorf("PathTemplate(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestTrimHost(t *testing.T) {
+	tests := []struct {
+		apiName string
+		want    string
+	}{
+		{"{host}/item", "/item"},
+		{"{consulHost}/item/{id}", "/item/{id}"},
+		{"http://localhost:9000/item", "/item"},
+		{"https://example.com", "/"},
+		{"/item", "/item"},
+		{"{id}{suffix}", "{id}{suffix}"}, // not a host, followed by another placeholder
+	}
+	for _, tt := range tests {
+		if got := TrimHost(tt.apiName); got != tt.want {
+			t.Errorf("TrimHost(%q) = %q, want %q", tt.apiName, got, tt.want)
+		}
+	}
+}
+
+func TestMatchTemplate(t *testing.T) {
+	tests := []struct {
+		route, test string
+		literal     int
+		ok          bool
+	}{
+		{"/item/{}", "/item/10", 1, true},
+		{"/item/{}", "/item/{}", 2, true}, // equal segment count as literal
+		{"/item/{}", "/item", 0, false},
+		{"/item/{}", "/item/10/detail", 0, false},
+		{"/item/list", "/item/10", 0, false},
+		{"/static/*", "/static/css/app.css", 1, true},
+		{"/*", "/anything", 0, true},
+		{"/", "/", 1, true},
+	}
+	for _, tt := range tests {
+		literal, ok := matchTemplate(tt.route, tt.test)
+		if ok != tt.ok || (ok && literal != tt.literal) {
+			t.Errorf("matchTemplate(%q, %q) = %d, %v, want %d, %v", tt.route, tt.test, literal, ok, tt.literal, tt.ok)
+		}
+	}
+}
+
+func TestFindRoute(t *testing.T) {
+	routes := make(map[string]Route)
+	for _, r := range []Route{
+		{Method: "GET", Path: "/item/{id}"},
+		{Method: "GET", Path: "/item/list"},
+		{Method: "POST", Path: "/item"},
+		{Method: "GET", Path: "/item/{id}/review/{reviewId}"},
+		{Method: AnyMethod, Path: "/health"},
+		{Method: "GET", Path: "/static/*"},
+		{Method: "GET", Path: "/static/css/*"},
+		{Method: AnyMethod, Path: "/{path...}"},
+	} {
+		routes[r.Key()] = r
+	}
+
+	tests := []struct {
+		apiName, method string
+		want            string
+	}{
+		{"{host}/item/{itemId}", "GET", "/item/{}GET"},             // exact template
+		{"{host}/item/10?x=1", "GET", "/item/{}GET"},               // param value and query string
+		{"{host}/item/list/", "GET", "/item/listGET"},              // literal is more specific than param
+		{"http://localhost:9000/item", "POST", "/itemPOST"},        // host trimmed
+		{"{host}/item/1/review/2", "GET", "/item/{}/review/{}GET"}, // several param
+		{"{host}/health", "DELETE", "/healthANY"},                  // route of every method
+		{"{host}/static/css/app.css", "GET", "/static/css/*GET"},   // most specific catch all
+		{"{host}/static/js/app.js", "GET", "/static/*GET"},         // catch all
+		{"{host}/item", "GET", "/*ANY"},                            // no GET route, fall back to catch all of every method
+		{"{consulHost}/unknown/route", "PUT", "/*ANY"},             // catch all of every method
+		{"{host}/item/{id}", "PATCH", "/*ANY"},                     // method not registered
+	}
+	for _, tt := range tests {
+		got, ok := FindRoute(routes, tt.apiName, tt.method)
+		if !ok || got != tt.want {
+			t.Errorf("FindRoute(%q, %q) = %q, %v, want %q", tt.apiName, tt.method, got, ok, tt.want)
+		}
+	}
+
+	delete(routes, "/*ANY")
+	if got, ok := FindRoute(routes, "{host}/unknown", "GET"); ok {
+		t.Errorf("FindRoute(unknown) = %q, want no route", got)
+	}
+}
@@ -0,0 +1,777 @@
+package api
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Route is an endpoint found in app source or OpenAPI spec
+type Route struct {
+	Method  string         // http method in upper case, eg. GET
+	Path    string         // fully qualified path, eg. /v1/item/{id}
+	Handler string         // handler expression, eg. h.GetItem, or operationId for OpenAPI
+	Pos     token.Position // where the route is registered or declared
+
+	// only set for OpenAPI operation
+	OperationID string
+	Summary     string
+	Tags        []string
+	Deprecated  bool
+
+	// only set for route exposed by grpc-gateway
+	RPC      string   // grpc method of the route, eg. sampleapp.Sampleapp/GetProduct
+	RPCTests []string // grpc integration test of RPC, it also cover the route
+}
+
+// Key return key used to match route with integration test, eg. /item/{}GET
+func (r Route) Key() string {
+	return PathTemplate(r.Path) + r.Method
+}
+
+// DiscoverRoutes will parse every go file in the package of path and return every route
+// path can be the package directory or one of its file (eg. http.go)
+// route is recognized by adapters, if empty the adapter is detected from the package imports
+func DiscoverRoutes(path string, adapters ...Adapter) ([]Route, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	dir := path
+	if !info.IsDir() {
+		dir = filepath.Dir(path)
+	}
+
+	// group go file in dir by its package, test file is ignored
+	fset := token.NewFileSet()
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var pkgNames []string
+	pkgs := make(map[string][]*ast.File)
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := pkgs[file.Name.Name]; !ok {
+			pkgNames = append(pkgNames, file.Name.Name)
+		}
+		pkgs[file.Name.Name] = append(pkgs[file.Name.Name], file)
+	}
+
+	var routes []Route
+	for _, pkgName := range pkgNames {
+		found := adapters
+		if len(found) == 0 {
+			found = detectAdapters(pkgs[pkgName])
+		}
+		routes = append(routes, newDiscoverer(fset, pkgs[pkgName], found).discover()...)
+	}
+	return routes, nil
+}
+
+// router is a router value found in app source, eg. chi.NewRouter() or r.PathPrefix("/v1").Subrouter()
+// route registered on a router is prefixed by the prefix of the router and all of its parent
+type router struct {
+	parent *router
+	prefix string
+}
+
+// fullPrefix return prefix of r joined with prefix of all of its parent
+func (r *router) fullPrefix() string {
+	if r == nil {
+		return ""
+	}
+	return joinPath(r.parent.fullPrefix(), r.prefix)
+}
+
+// hasAncestor will check if a is r or one of r parent
+func (r *router) hasAncestor(a *router) bool {
+	for ; r != nil; r = r.parent {
+		if r == a {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingRoute is a route whose prefix is only known after every router is mounted
+type pendingRoute struct {
+	router *router
+	Route
+}
+
+// env hold local variable that is bound to a router
+type env map[string]*router
+
+func (e env) copy() env {
+	c := make(env, len(e))
+	for k, v := range e {
+		c[k] = v
+	}
+	return c
+}
+
+// discoverer find route inside one package
+// every function is walked statement by statement to track which router each variable hold
+type discoverer struct {
+	fset     *token.FileSet
+	files    []*ast.File
+	adapters []Adapter
+	consts   map[string]ast.Expr          // constant declared in the package, keyed by name
+	funcs    map[string]*ast.FuncDecl     // function declared in the package, keyed by name
+	methods  map[string]*ast.FuncDecl     // method declared in the package, keyed by receiver type and name, eg. Server.routes
+	named    map[string][]*ast.FuncDecl   // method declared in the package, keyed by name only
+	fields   map[string]map[string]string // field type of struct declared in the package, keyed by struct and field name
+	embeds   map[string][]string          // embedded field type of struct declared in the package
+	globals  map[string]*router           // router that is not a local variable, eg. s.router, keyed by expression
+	walking  map[*ast.FuncDecl]bool       // function currently walked, used to stop recursion
+	walked   map[*ast.FuncDecl]bool
+	scopes   []map[string]string // type of local variable of every function currently walked, the last is the innermost
+	routes   []pendingRoute
+}
+
+func newDiscoverer(fset *token.FileSet, files []*ast.File, adapters []Adapter) *discoverer {
+	d := &discoverer{
+		fset:     fset,
+		files:    files,
+		adapters: adapters,
+		consts:   make(map[string]ast.Expr),
+		funcs:    make(map[string]*ast.FuncDecl),
+		methods:  make(map[string]*ast.FuncDecl),
+		named:    make(map[string][]*ast.FuncDecl),
+		fields:   make(map[string]map[string]string),
+		embeds:   make(map[string][]string),
+		globals:  make(map[string]*router),
+		walking:  make(map[*ast.FuncDecl]bool),
+		walked:   make(map[*ast.FuncDecl]bool),
+	}
+	for _, file := range files {
+		d.collectConsts(file)
+		d.collectStructs(file)
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			if fn.Recv == nil || len(fn.Recv.List) == 0 {
+				if _, exist := d.funcs[fn.Name.Name]; !exist {
+					d.funcs[fn.Name.Name] = fn
+				}
+				continue
+			}
+			// same method name on other receiver type is another method, eg. itemHandler.routes and userHandler.routes
+			key := typeName(fn.Recv.List[0].Type) + "." + fn.Name.Name
+			if _, exist := d.methods[key]; !exist {
+				d.methods[key] = fn
+				d.named[fn.Name.Name] = append(d.named[fn.Name.Name], fn)
+			}
+		}
+	}
+	return d
+}
+
+// discover will walk every function and return every route found
+// function that is never used inside the package (eg. main, or called from other package) is walked first
+// so helper function like func itemRoutes(r chi.Router) is walked with the router it is called with
+func (d *discoverer) discover() []Route {
+	referenced := d.referencedFuncs()
+	var entries, rest []*ast.FuncDecl
+	for _, file := range d.files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			if referenced[fn] {
+				rest = append(rest, fn)
+			} else {
+				entries = append(entries, fn)
+			}
+		}
+	}
+	for _, fn := range append(entries, rest...) {
+		if !d.walked[fn] {
+			d.walkFunc(fn, nil, nil)
+		}
+	}
+
+	routes := make([]Route, 0, len(d.routes))
+	for _, r := range d.routes {
+		route := r.Route
+		route.Path = joinPath(r.router.fullPrefix(), route.Path)
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// referencedFuncs return every function and method used inside a function body of the package
+func (d *discoverer) referencedFuncs() map[*ast.FuncDecl]bool {
+	referenced := make(map[*ast.FuncDecl]bool)
+	for _, file := range d.files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			scope := d.scopeOf(fn)
+			selected := make(map[*ast.Ident]bool) // method name of a selector, it is not a function
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				switch x := n.(type) {
+				case *ast.SelectorExpr:
+					selected[x.Sel] = true
+					if method := d.methodOf(x.X, x.Sel.Name, scope); method != nil {
+						referenced[method] = true
+					}
+				case *ast.Ident:
+					if decl, ok := d.funcs[x.Name]; ok && !selected[x] {
+						referenced[decl] = true
+					}
+				}
+				return true
+			})
+		}
+	}
+	return referenced
+}
+
+// collectStructs will record field type of every struct declared in node
+// so method called on a field (eg. s.items.routes()) is resolved by the field type
+func (d *discoverer) collectStructs(node ast.Node) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+		fields := make(map[string]string)
+		for _, field := range st.Fields.List {
+			typ := typeName(field.Type)
+			if len(field.Names) == 0 { // embedded field is named by its type, eg. *Base -> Base
+				d.embeds[spec.Name.Name] = append(d.embeds[spec.Name.Name], typ)
+				fields[typ[strings.LastIndex(typ, ".")+1:]] = typ
+			}
+			for _, name := range field.Names {
+				fields[name.Name] = typ
+			}
+		}
+		d.fields[spec.Name.Name] = fields
+		return false
+	})
+}
+
+// scopeOf return type of receiver, parameter and local variable of fn keyed by name
+// type is only known when it is declared or assigned from a composite literal, new or a call of function declared in the package
+// variable declared twice keep the first type
+func (d *discoverer) scopeOf(fn *ast.FuncDecl) map[string]string {
+	scope := make(map[string]string)
+	declare := func(name, typ string) {
+		if _, ok := scope[name]; !ok && typ != "" {
+			scope[name] = typ
+		}
+	}
+	declareFields := func(list *ast.FieldList) {
+		if list == nil {
+			return
+		}
+		for _, field := range list.List {
+			for _, name := range field.Names {
+				declare(name.Name, typeName(field.Type))
+			}
+		}
+	}
+	declareFields(fn.Recv)
+	declareFields(fn.Type.Params)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			declareFields(s.Type.Params)
+		case *ast.AssignStmt:
+			for i, lhs := range s.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if len(s.Lhs) == len(s.Rhs) {
+					declare(ident.Name, d.typeOf(s.Rhs[i], scope))
+				} else if i == 0 && len(s.Rhs) == 1 { // eg. h, err := newHandler()
+					declare(ident.Name, d.typeOf(s.Rhs[0], scope))
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range s.Names {
+				if s.Type != nil {
+					declare(name.Name, typeName(s.Type))
+				} else if i < len(s.Values) {
+					declare(name.Name, d.typeOf(s.Values[i], scope))
+				}
+			}
+		}
+		return true
+	})
+	return scope
+}
+
+// scope return type of local variable of the function currently walked
+func (d *discoverer) scope() map[string]string {
+	if len(d.scopes) == 0 {
+		return nil
+	}
+	return d.scopes[len(d.scopes)-1]
+}
+
+// typeOf return name of the type expr evaluate to, empty if it is unknown
+// eg. &itemHandler{} -> itemHandler, newItemHandler() -> itemHandler, s.items -> type of field items
+func (d *discoverer) typeOf(expr ast.Expr, scope map[string]string) string {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return scope[x.Name]
+	case *ast.ParenExpr:
+		return d.typeOf(x.X, scope)
+	case *ast.StarExpr:
+		return d.typeOf(x.X, scope)
+	case *ast.UnaryExpr:
+		if x.Op == token.AND {
+			return d.typeOf(x.X, scope)
+		}
+	case *ast.CompositeLit:
+		return typeName(x.Type)
+	case *ast.SelectorExpr:
+		if typ := d.typeOf(x.X, scope); typ != "" {
+			return d.fields[typ][x.Sel.Name]
+		}
+	case *ast.CallExpr:
+		if ident, ok := x.Fun.(*ast.Ident); ok && ident.Name == "new" && len(x.Args) == 1 {
+			return typeName(x.Args[0])
+		}
+		var fn *ast.FuncDecl
+		switch fun := x.Fun.(type) {
+		case *ast.Ident:
+			fn = d.funcs[fun.Name]
+		case *ast.SelectorExpr:
+			fn = d.methodOf(fun.X, fun.Sel.Name, scope)
+		}
+		if fn != nil && fn.Type.Results != nil && len(fn.Type.Results.List) > 0 {
+			return typeName(fn.Type.Results.List[0].Type)
+		}
+	}
+	return ""
+}
+
+// methodOf return method name called on x, nil if it is not declared in the package
+// when type of x is unknown, the method is only resolved if no other type declare the same name
+func (d *discoverer) methodOf(x ast.Expr, name string, scope map[string]string) *ast.FuncDecl {
+	if typ := d.typeOf(x, scope); typ != "" {
+		return d.methodOn(typ, name, make(map[string]bool))
+	}
+	if candidates := d.named[name]; len(candidates) == 1 {
+		return candidates[0]
+	}
+	return nil
+}
+
+// methodOn return method name of typ, including method promoted from embedded field
+func (d *discoverer) methodOn(typ, name string, visited map[string]bool) *ast.FuncDecl {
+	if fn, ok := d.methods[typ+"."+name]; ok {
+		return fn
+	}
+	if visited[typ] {
+		return nil
+	}
+	visited[typ] = true
+	for _, embed := range d.embeds[typ] {
+		if fn := d.methodOn(embed, name, visited); fn != nil {
+			return fn
+		}
+	}
+	return nil
+}
+
+// typeName return name of type expression without pointer and type argument, eg. *Server[T] -> Server
+// type of other package keep its package name, so it never match a method declared in the package
+func typeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return typeName(t.X)
+	case *ast.ParenExpr:
+		return typeName(t.X)
+	case *ast.IndexExpr:
+		return typeName(t.X)
+	case *ast.IndexListExpr:
+		return typeName(t.X)
+	case *ast.SelectorExpr:
+		return types.ExprString(t)
+	}
+	return ""
+}
+
+// collectConsts will record every constant declared in node
+// so it can be resolved when used as route path
+func (d *discoverer) collectConsts(node ast.Node) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		decl, ok := n.(*ast.GenDecl)
+		if !ok || decl.Tok != token.CONST {
+			return true
+		}
+		for _, spec := range decl.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if i < len(vs.Values) {
+					d.consts[name.Name] = vs.Values[i]
+				}
+			}
+		}
+		return false
+	})
+}
+
+// walkFunc will walk fn with its receiver bound to recv and its parameter bound to args
+// return the router returned by fn if any
+func (d *discoverer) walkFunc(fn *ast.FuncDecl, args []*router, recv *router) *router {
+	if d.walking[fn] {
+		return nil
+	}
+	d.walking[fn] = true
+	d.walked[fn] = true
+	d.scopes = append(d.scopes, d.scopeOf(fn))
+	defer func() {
+		delete(d.walking, fn)
+		d.scopes = d.scopes[:len(d.scopes)-1]
+	}()
+
+	e := make(env)
+	if recv != nil && fn.Recv != nil && len(fn.Recv.List) > 0 && len(fn.Recv.List[0].Names) > 0 {
+		e[fn.Recv.List[0].Names[0].Name] = recv
+	}
+	bindParams(e, fn.Type, args)
+
+	var returned *router
+	d.walkBlock(fn.Body, e, &returned)
+	return returned
+}
+
+// bindParams will bind every parameter of fn to args in order
+func bindParams(e env, fn *ast.FuncType, args []*router) {
+	i := 0
+	for _, field := range fn.Params.List {
+		if len(field.Names) == 0 { // unnamed parameter
+			i++
+			continue
+		}
+		for _, name := range field.Names {
+			if i < len(args) && args[i] != nil {
+				e[name.Name] = args[i]
+			}
+			i++
+		}
+	}
+}
+
+// walkBlock will walk every statement in block in order
+// returned is set to the first router returned by a return statement
+func (d *discoverer) walkBlock(block *ast.BlockStmt, e env, returned **router) {
+	if block == nil {
+		return
+	}
+	for _, stmt := range block.List {
+		d.walkStmt(stmt, e, returned)
+	}
+}
+
+func (d *discoverer) walkStmt(stmt ast.Stmt, e env, returned **router) {
+	switch s := stmt.(type) {
+	case *ast.BlockStmt:
+		d.walkBlock(s, e, returned)
+	case *ast.ExprStmt:
+		d.eval(s.X, e)
+	case *ast.AssignStmt:
+		for i, rhs := range s.Rhs {
+			r := d.eval(rhs, e)
+			if r != nil && len(s.Lhs) == len(s.Rhs) {
+				d.bind(s.Lhs[i], r, e)
+			}
+		}
+	case *ast.DeclStmt:
+		gen, ok := s.Decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			return
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, value := range vs.Values {
+				if r := d.eval(value, e); r != nil && i < len(vs.Names) {
+					d.bind(vs.Names[i], r, e)
+				}
+			}
+		}
+	case *ast.ReturnStmt:
+		for _, result := range s.Results {
+			if r := d.eval(result, e); r != nil && *returned == nil {
+				*returned = r
+			}
+		}
+	case *ast.IfStmt:
+		if s.Init != nil {
+			d.walkStmt(s.Init, e, returned)
+		}
+		d.eval(s.Cond, e)
+		d.walkBlock(s.Body, e, returned)
+		if s.Else != nil {
+			d.walkStmt(s.Else, e, returned)
+		}
+	case *ast.ForStmt:
+		d.walkBlock(s.Body, e, returned)
+	case *ast.RangeStmt:
+		d.eval(s.X, e)
+		d.walkBlock(s.Body, e, returned)
+	case *ast.SwitchStmt:
+		d.walkBlock(s.Body, e, returned)
+	case *ast.TypeSwitchStmt:
+		d.walkBlock(s.Body, e, returned)
+	case *ast.CaseClause:
+		for _, body := range s.Body {
+			d.walkStmt(body, e, returned)
+		}
+	case *ast.SelectStmt:
+		d.walkBlock(s.Body, e, returned)
+	case *ast.CommClause:
+		for _, body := range s.Body {
+			d.walkStmt(body, e, returned)
+		}
+	case *ast.LabeledStmt:
+		d.walkStmt(s.Stmt, e, returned)
+	case *ast.GoStmt:
+		d.eval(s.Call, e)
+	case *ast.DeferStmt:
+		d.eval(s.Call, e)
+	}
+}
+
+// bind will remember that lhs hold router r
+// local variable is kept in e, the rest (eg. s.router) in globals
+func (d *discoverer) bind(lhs ast.Expr, r *router, e env) {
+	switch l := lhs.(type) {
+	case *ast.Ident:
+		if l.Name != "_" {
+			e[l.Name] = r
+		}
+	case *ast.SelectorExpr:
+		d.globals[types.ExprString(l)] = r
+	}
+}
+
+// routerOf return router held by expr
+// unknown expression, eg. parameter of a function walked as entry, is a root router
+func (d *discoverer) routerOf(expr ast.Expr, e env) *router {
+	if r := d.eval(expr, e); r != nil {
+		return r
+	}
+	key := types.ExprString(expr)
+	if _, ok := d.globals[key]; !ok {
+		d.globals[key] = &router{}
+	}
+	return d.globals[key]
+}
+
+// eval will evaluate expr, registering every route and router found in it
+// return the router expr evaluate to, nil if expr is not a router
+func (d *discoverer) eval(expr ast.Expr, e env) *router {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		if r, ok := e[x.Name]; ok {
+			return r
+		}
+		return d.globals[x.Name]
+	case *ast.SelectorExpr:
+		return d.globals[types.ExprString(x)]
+	case *ast.ParenExpr:
+		return d.eval(x.X, e)
+	case *ast.FuncLit:
+		// closure can use every variable of its parent
+		var returned *router
+		d.walkBlock(x.Body, e.copy(), &returned)
+	case *ast.UnaryExpr:
+		d.eval(x.X, e)
+	case *ast.CompositeLit:
+		for _, elt := range x.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			d.eval(elt, e)
+		}
+	case *ast.CallExpr:
+		return d.evalCall(x, e)
+	}
+	return nil
+}
+
+// evalCall will evaluate function or method call
+func (d *discoverer) evalCall(call *ast.CallExpr, e env) *router {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		// function declared in the package, eg. itemRoutes(r)
+		if fn, ok := d.funcs[fun.Name]; ok {
+			return d.walkFunc(fn, d.evalArgs(call.Args, e), nil)
+		}
+	case *ast.SelectorExpr:
+		if r, ok := d.evalRouterCall(call, e); ok {
+			return r
+		}
+		// method declared in the package, eg. s.routes()
+		if fn := d.methodOf(fun.X, fun.Sel.Name, d.scope()); fn != nil {
+			return d.walkFunc(fn, d.evalArgs(call.Args, e), d.eval(fun.X, e))
+		}
+		d.eval(fun.X, e)
+	default:
+		d.eval(call.Fun, e)
+	}
+	d.evalArgs(call.Args, e)
+	return nil
+}
+
+// evalArgs will evaluate every argument and return the router each argument evaluate to
+func (d *discoverer) evalArgs(args []ast.Expr, e env) []*router {
+	routers := make([]*router, len(args))
+	for i, arg := range args {
+		routers[i] = d.eval(arg, e)
+	}
+	return routers
+}
+
+// evalRouterCall will evaluate call that create a router, register a route or a sub router
+// ok is false if no adapter recognize call
+func (d *discoverer) evalRouterCall(call *ast.CallExpr, e env) (r *router, ok bool) {
+	for _, a := range d.adapters {
+		// eg. chi.NewRouter(), mux.NewRouter()
+		if a.NewRouter(call) {
+			return &router{}, true
+		}
+
+		// eg. r.Get("/item", h.GetItem)
+		if rc, ok := a.Route(call, d.stringValue); ok {
+			methods := rc.Methods
+			if len(methods) == 0 {
+				methods = []string{AnyMethod}
+			}
+			parent := d.routerOf(rc.Router, e)
+			for _, method := range methods {
+				d.routes = append(d.routes, pendingRoute{
+					router: parent,
+					Route: Route{
+						Method:  method,
+						Path:    rc.Path,
+						Handler: types.ExprString(rc.Handler),
+						Pos:     d.fset.Position(call.Pos()),
+					},
+				})
+			}
+			d.eval(rc.Handler, e)
+			return nil, true
+		}
+
+		// eg. r.Route("/v1", func(r chi.Router) { ... })
+		if sc, ok := a.Subrouter(call, d.stringValue); ok {
+			parent := d.routerOf(sc.Parent, e)
+			if sc.Mount != nil {
+				// only root router can be mounted, mounted router is not mounted again
+				sub := d.eval(sc.Mount, e)
+				if sub != nil && sub.parent == nil && !parent.hasAncestor(sub) {
+					sub.parent = parent
+					sub.prefix = sc.Prefix
+				}
+				return nil, true
+			}
+			child := &router{parent: parent, prefix: sc.Prefix}
+			if sc.Callback != nil {
+				d.walkCallback(sc.Callback, child, e)
+			}
+			return child, true
+		}
+	}
+	return nil, false
+}
+
+// walkCallback will walk function that receive a router as its first parameter
+// eg. func(r chi.Router) { ... } or itemRoutes
+func (d *discoverer) walkCallback(expr ast.Expr, r *router, e env) {
+	switch fn := expr.(type) {
+	case *ast.FuncLit:
+		child := e.copy()
+		bindParams(child, fn.Type, []*router{r})
+		var returned *router
+		d.walkBlock(fn.Body, child, &returned)
+	case *ast.Ident:
+		if decl, ok := d.funcs[fn.Name]; ok {
+			d.walkFunc(decl, []*router{r}, nil)
+		}
+	case *ast.SelectorExpr:
+		if decl := d.methodOf(fn.X, fn.Sel.Name, d.scope()); decl != nil {
+			d.walkFunc(decl, []*router{r}, d.eval(fn.X, e))
+		}
+	}
+}
+
+// stringValue will resolve string literal, constant and concatenation of both
+// eg. prefix + "/item" where const prefix = "/v1"
+func (d *discoverer) stringValue(expr ast.Expr) (string, bool) {
+	return d.resolve(expr, make(map[string]bool))
+}
+
+func (d *discoverer) resolve(expr ast.Expr, visited map[string]bool) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(e.Value)
+		return value, err == nil
+	case *ast.ParenExpr:
+		return d.resolve(e.X, visited)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		x, ok := d.resolve(e.X, visited)
+		if !ok {
+			return "", false
+		}
+		y, ok := d.resolve(e.Y, visited)
+		return x + y, ok
+	case *ast.Ident:
+		value, ok := d.consts[e.Name]
+		if !ok || visited[e.Name] { // unknown or recursive constant
+			return "", false
+		}
+		visited[e.Name] = true
+		defer delete(visited, e.Name)
+		return d.resolve(value, visited)
+	}
+	return "", false
+}
+
+// joinPath will join router prefix and route path
+// eg. /v1 + /item -> /v1/item, /v1 + / -> /v1
+func joinPath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "" || path == "/" {
+		return prefix
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+}
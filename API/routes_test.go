@@ -0,0 +1,305 @@
+package api
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// discover will write src as a go file in a temp package and return every route found as "METHOD path handler"
+func discover(t *testing.T, src string, adapters ...Adapter) []string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(dir, "http.go"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	routes, err := DiscoverRoutes(dir, adapters...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range routes {
+		got = append(got, r.Method+" "+r.Path+" "+r.Handler)
+	}
+	sort.Strings(got)
+	return got
+}
+
+func TestDiscoverRoutes(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "chi Route Mount Group With",
+			src: `package http
+
+import "github.com/go-chi/chi/v5"
+
+func Router(h *Handler) chi.Router {
+	r := chi.NewRouter()
+	r.Get("/health", h.Health)
+	r.Route("/v1", func(r chi.Router) {
+		r.Post("/item", h.CreateItem)
+		r.Group(func(r chi.Router) {
+			r.Use(auth)
+			r.Delete("/item/{id}", h.DeleteItem)
+		})
+		r.With(auth).Put("/item/{id}", h.UpdateItem)
+		r.Route("/cart", cartRoutes)
+	})
+	r.Mount("/admin", adminRouter(h))
+	r.Handle("/static/*", h.Static)
+	r.Method("PATCH", "/item/{id}", h.PatchItem)
+	return r
+}
+
+func cartRoutes(r chi.Router) {
+	r.Get("/", h.GetCart)
+}
+
+func adminRouter(h *Handler) chi.Router {
+	r := chi.NewRouter()
+	r.Get("/user", h.ListUser)
+	return r
+}
+`,
+			want: []string{
+				"ANY /static/* h.Static",
+				"DELETE /v1/item/{id} h.DeleteItem",
+				"GET /admin/user h.ListUser",
+				"GET /health h.Health",
+				"GET /v1/cart h.GetCart",
+				"PATCH /item/{id} h.PatchItem",
+				"POST /v1/item h.CreateItem",
+				"PUT /v1/item/{id} h.UpdateItem",
+			},
+		},
+		{
+			name: "gorilla PathPrefix Subrouter and Handler, Methods chain",
+			src: `package http
+
+import "github.com/gorilla/mux"
+
+func Router(h *Handler) *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc("/health", h.Health).Methods("GET", "HEAD")
+	api := r.PathPrefix("/api").Subrouter()
+	v1 := api.PathPrefix("/v1").Subrouter()
+	v1.HandleFunc("/item/{id:[0-9]+}", h.GetItem).Methods("GET").Name("item")
+	v1.Path("/item").Methods("POST").HandlerFunc(h.CreateItem)
+	v1.Handle("/any", h.Any)
+	r.PathPrefix("/static/").Handler(h.Static)
+	r.PathPrefix("/assets").Methods("GET").HandlerFunc(h.Assets)
+	return r
+}
+`,
+			want: []string{
+				"ANY /api/v1/any h.Any",
+				"ANY /static/* h.Static",
+				"GET /api/v1/item/{id:[0-9]+} h.GetItem",
+				"GET /assets/* h.Assets",
+				"GET /health h.Health",
+				"HEAD /health h.Health",
+				"POST /api/v1/item h.CreateItem",
+			},
+		},
+		{
+			name: "gin Group",
+			src: `package http
+
+import "github.com/gin-gonic/gin"
+
+func Router(h *Handler) *gin.Engine {
+	r := gin.Default()
+	r.GET("/health", h.Health)
+	v1 := r.Group("/v1", auth)
+	{
+		v1.POST("/item", validate, h.CreateItem)
+		item := v1.Group("/item")
+		item.GET("/:id", h.GetItem)
+		item.Handle("DELETE", "/:id", h.DeleteItem)
+	}
+	r.Any("/proxy/*path", h.Proxy)
+	return r
+}
+`,
+			want: []string{
+				"ANY /proxy/*path h.Proxy",
+				"DELETE /v1/item/:id h.DeleteItem",
+				"GET /health h.Health",
+				"GET /v1/item/:id h.GetItem",
+				"POST /v1/item h.CreateItem",
+			},
+		},
+		{
+			name: "echo Group",
+			src: `package http
+
+import "github.com/labstack/echo/v4"
+
+func Router(h *Handler) *echo.Echo {
+	e := echo.New()
+	e.GET("/health", h.Health, logger)
+	g := e.Group("/v1")
+	g.PUT("/item/:id", h.UpdateItem)
+	g.Add("PATCH", "/item/:id", h.PatchItem)
+	g.Match([]string{"GET", "HEAD"}, "/item", h.ListItem)
+	return e
+}
+`,
+			want: []string{
+				"GET /health h.Health",
+				"GET /v1/item h.ListItem",
+				"HEAD /v1/item h.ListItem",
+				"PATCH /v1/item/:id h.PatchItem",
+				"PUT /v1/item/:id h.UpdateItem",
+			},
+		},
+		{
+			name: "net/http pattern",
+			src: `package http
+
+import "net/http"
+
+func Router(h *Handler) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /x/{id}", h.GetX)
+	mux.HandleFunc("POST example.com/x", h.CreateX)
+	mux.Handle("/files/{path...}", h.Files)
+	mux.HandleFunc("GET /{$}", h.Index)
+	http.HandleFunc("/legacy", h.Legacy)
+	return mux
+}
+`,
+			want: []string{
+				"ANY /files/{path...} h.Files",
+				"ANY /legacy h.Legacy",
+				"GET /x/{id} h.GetX",
+				"GET /{$} h.Index",
+				"POST /x h.CreateX",
+			},
+		},
+		{
+			name: "constant and concatenated path",
+			src: `package http
+
+import "github.com/go-chi/chi"
+
+const (
+	apiPrefix = "/api"
+	v1        = apiPrefix + "/v1"
+	itemPath  = "/item"
+)
+
+func Router(h *Handler) chi.Router {
+	r := chi.NewRouter()
+	r.Route(v1, func(r chi.Router) {
+		r.Get(itemPath+"/{id}", h.GetItem)
+		r.Post(itemPath, h.CreateItem)
+	})
+	r.Get(apiPrefix+"/health", h.Health)
+	return r
+}
+`,
+			want: []string{
+				"GET /api/health h.Health",
+				"GET /api/v1/item/{id} h.GetItem",
+				"POST /api/v1/item h.CreateItem",
+			},
+		},
+		{
+			name: "method of the same name on other receiver type",
+			src: `package http
+
+import "github.com/go-chi/chi/v5"
+
+type Server struct {
+	admin *adminHandler
+}
+
+type itemHandler struct{}
+
+type userHandler struct{}
+
+type adminHandler struct{}
+
+func newUserHandler() *userHandler {
+	return &userHandler{}
+}
+
+func (h *itemHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.ListItem)
+}
+
+func (h *userHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.ListUser)
+}
+
+func (a *adminHandler) routes() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/user", a.ListUser)
+	return r
+}
+
+func (s *Server) routes() chi.Router {
+	r := chi.NewRouter()
+	ih := &itemHandler{}
+	uh := newUserHandler()
+	r.Route("/items", ih.RegisterRoutes)
+	r.Route("/users", uh.RegisterRoutes)
+	r.Mount("/admin", s.admin.routes())
+	return r
+}
+`,
+			want: []string{
+				"GET /admin/user a.ListUser",
+				"GET /items h.ListItem",
+				"GET /users h.ListUser",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := discover(t, tt.src)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("routes\ngot  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectAdapters(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"chi major version", `package http; import "github.com/go-chi/chi/v5"`, []string{"chi"}},
+		{"gin with net/http", "package http\nimport (\n\"net/http\"\n\"github.com/gin-gonic/gin\"\n)", []string{"gin"}},
+		{"net/http only", `package http; import "net/http"`, []string{"nethttp"}},
+		{"nothing", `package http`, []string{"chi"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parser.ParseFile(token.NewFileSet(), "http.go", tt.src, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files := []*ast.File{file}
+			var got []string
+			for _, a := range detectAdapters(files) {
+				got = append(got, a.Name())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("detectAdapters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
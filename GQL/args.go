@@ -0,0 +1,111 @@
+package gql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	gqlast "github.com/vektah/gqlparser/v2/ast"
+)
+
+// argumentSheetName is the sheet listing every argument of every operation
+const argumentSheetName = "Arguments"
+
+// argumentUsage hold test case name that supply each argument of each operation
+type argumentUsage map[*Operation]map[string][]string
+
+// add will record that test supplied every argument in args of op
+func (u argumentUsage) add(op *Operation, args []string, test string) {
+	if u[op] == nil {
+		u[op] = make(map[string][]string)
+	}
+	for _, arg := range args {
+		u[op][arg] = appendUnique(u[op][arg], test)
+	}
+}
+
+// suppliedArgs return name of every argument supplied to the selected field, either as literal or variable
+// argument with variable value is only supplied if the variable is set in variables or has a default value
+func suppliedArgs(selection Selection, variables map[string]interface{}) []string {
+	var args []string
+	for _, arg := range selection.Field.Arguments {
+		if arg.Value == nil {
+			continue
+		}
+		if arg.Value.Kind == gqlast.Variable {
+			value, ok := variables[arg.Value.Raw]
+			if !ok || value == nil {
+				def := selection.Operation.VariableDefinitions.ForName(arg.Value.Raw)
+				if def == nil || def.DefaultValue == nil {
+					continue
+				}
+			}
+		}
+		args = append(args, arg.Name)
+	}
+	return args
+}
+
+// writeArgumentSheet will list every argument of operations and the test case that supply it
+// argument that is never supplied is marked No TestCase, so untested required and optional argument can be filtered
+func writeArgumentSheet(xlsx *excelize.File, operations []*Operation, usage argumentUsage, status []string) {
+	xlsx.NewSheet(argumentSheetName)
+	sheet := argumentSheetName
+
+	// create column name
+	xlsx.SetCellValue(sheet, "A1", "Endpoint")
+	xlsx.SetCellValue(sheet, "B1", "Type")
+	xlsx.SetCellValue(sheet, "C1", "Argument")
+	xlsx.SetCellValue(sheet, "D1", "Argument Type")
+	xlsx.SetCellValue(sheet, "E1", "Required")
+	xlsx.SetCellValue(sheet, "F1", "Tested")
+	xlsx.SetCellValue(sheet, "G1", "Test Case Name")
+	xlsx.SetCellValue(sheet, "H1", "Status")
+	xlsx.SetCellValue(sheet, "I1", "Notes")
+	xlsx.AutoFilter(sheet, "A1", "I1", "")
+
+	// add data validation for status column
+	dvRange := excelize.NewDataValidation(true)
+	dvRange.Sqref = "H:H"
+	dvRange.SetDropList(status)
+	xlsx.AddDataValidation(sheet, dvRange)
+
+	row := 1
+	for _, op := range operations {
+		if len(op.Args) == 0 {
+			continue
+		}
+		start := row + 1
+		for _, arg := range op.Args {
+			row++
+			tests := usage[op][arg.Name]
+			xlsx.SetCellValue(sheet, fmt.Sprintf("A%d", row), op.Name)
+			xlsx.SetCellValue(sheet, fmt.Sprintf("B%d", row), op.SheetType())
+			xlsx.SetCellValue(sheet, fmt.Sprintf("C%d", row), arg.Name)
+			xlsx.SetCellValue(sheet, fmt.Sprintf("D%d", row), arg.Type)
+			xlsx.SetCellValue(sheet, fmt.Sprintf("E%d", row), yesNo(arg.Required()))
+			xlsx.SetCellValue(sheet, fmt.Sprintf("F%d", row), yesNo(len(tests) > 0))
+			xlsx.SetCellValue(sheet, fmt.Sprintf("G%d", row), strings.Join(tests, ", "))
+			if len(tests) > 0 {
+				xlsx.SetCellValue(sheet, fmt.Sprintf("H%d", row), "Live")
+			} else {
+				xlsx.SetCellValue(sheet, fmt.Sprintf("H%d", row), "No TestCase")
+			}
+			if arg.Default != "" {
+				xlsx.SetCellValue(sheet, fmt.Sprintf("I%d", row), "default "+arg.Default)
+			}
+		}
+
+		// merge cell of the same endpoint
+		xlsx.MergeCell(sheet, "A"+strconv.Itoa(start), "A"+strconv.Itoa(row))
+		xlsx.MergeCell(sheet, "B"+strconv.Itoa(start), "B"+strconv.Itoa(row))
+	}
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
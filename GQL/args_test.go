@@ -0,0 +1,89 @@
+package gql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuppliedArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		want      []string
+	}{
+		{
+			name:  "literal",
+			query: `{ getProduct(id: 1, lang: "en", filter: {tag: "a"}) { id } }`,
+			want:  []string{"id", "lang", "filter"},
+		},
+		{
+			name:      "variable",
+			query:     `query q($id: Int!, $lang: String) { getProduct(id: $id, lang: $lang) { id } }`,
+			variables: map[string]interface{}{"id": 1, "lang": "en"},
+			want:      []string{"id", "lang"},
+		},
+		{
+			name:      "variable with default value",
+			query:     `query q($id: Int!, $lang: String = "en") { getProduct(id: $id, lang: $lang) { id } }`,
+			variables: map[string]interface{}{"id": 1},
+			want:      []string{"id", "lang"},
+		},
+		{
+			name:      "variable not set or null",
+			query:     `query q($id: Int!, $lang: String) { getProduct(id: $id, lang: $lang) { id } }`,
+			variables: map[string]interface{}{"lang": nil},
+		},
+		{
+			name:  "never supplied",
+			query: `{ getProduct { id } }`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selections, err := Selections(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := suppliedArgs(selections[0], tt.variables); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("suppliedArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgumentUsage(t *testing.T) {
+	op := &Operation{Root: Query, Name: "getProduct", Args: []Argument{{Name: "id", Type: "Int!"}, {Name: "lang", Type: "String"}}}
+	usage := make(argumentUsage)
+	usage.add(op, []string{"id"}, "get product")
+	usage.add(op, []string{"id", "lang"}, "get product in english")
+	usage.add(op, []string{"id"}, "get product")
+
+	want := map[string][]string{
+		"id":   {"get product", "get product in english"},
+		"lang": {"get product in english"},
+	}
+	if !reflect.DeepEqual(usage[op], want) {
+		t.Errorf("usage = %v, want %v", usage[op], want)
+	}
+	// argument that is never supplied has no test case
+	if tests := usage[&Operation{Name: "getCart"}]["id"]; tests != nil {
+		t.Errorf("usage of never supplied argument = %v", tests)
+	}
+}
+
+func TestArgumentRequired(t *testing.T) {
+	tests := []struct {
+		arg  Argument
+		want bool
+	}{
+		{Argument{Name: "id", Type: "Int!"}, true},
+		{Argument{Name: "lang", Type: "String!", Default: `"en"`}, false},
+		{Argument{Name: "tag", Type: "[String!]"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.arg.Required(); got != tt.want {
+			t.Errorf("%s.Required() = %v, want %v", tt.arg.Name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package gql
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	gqlast "github.com/vektah/gqlparser/v2/ast"
+)
+
+// fieldSheetName is the sheet listing every output field of every type returned by an operation
+const fieldSheetName = "Fields"
+
+// fieldUsage hold test case name that select each field of each type, keyed by type name then field name
+type fieldUsage map[string]map[string][]string
+
+// add will walk selection set of the selected root field against schema and record every field test select
+func (u fieldUsage) add(schema *Schema, op *Operation, selection Selection, test string) {
+	u.walk(schema, NamedType(op.Type), selection.Field.SelectionSet, selection.Document, make(map[string]bool), test)
+}
+
+// walk will record every field in set as selected on typeName
+// visited is fragment already expanded, used to stop recursive fragment
+func (u fieldUsage) walk(schema *Schema, typeName string, set gqlast.SelectionSet, doc *gqlast.QueryDocument, visited map[string]bool, test string) {
+	for _, selection := range set {
+		switch s := selection.(type) {
+		case *gqlast.Field:
+			obj, ok := schema.Types[typeName]
+			if !ok {
+				continue
+			}
+			field, ok := obj.Field(s.Name) // __typename and unknown field is skipped
+			if !ok {
+				continue
+			}
+			if u[typeName] == nil {
+				u[typeName] = make(map[string][]string)
+			}
+			u[typeName][s.Name] = appendUnique(u[typeName][s.Name], test)
+			u.walk(schema, NamedType(field.Type), s.SelectionSet, doc, visited, test)
+		case *gqlast.InlineFragment:
+			u.walkFragment(schema, typeName, s.TypeCondition, s.SelectionSet, doc, visited, test)
+		case *gqlast.FragmentSpread:
+			fragment := doc.Fragments.ForName(s.Name)
+			if fragment == nil || visited[s.Name] {
+				continue
+			}
+			visited[s.Name] = true
+			u.walkFragment(schema, typeName, fragment.TypeCondition, fragment.SelectionSet, doc, visited, test)
+			delete(visited, s.Name)
+		}
+	}
+}
+
+// walkFragment will record field of fragment on its type condition and on the enclosing typeName
+// eg. seller { ... on Node { id } } select both Node.id and Seller.id
+func (u fieldUsage) walkFragment(schema *Schema, typeName, condition string, set gqlast.SelectionSet, doc *gqlast.QueryDocument, visited map[string]bool, test string) {
+	if condition != "" && condition != typeName {
+		u.walk(schema, condition, set, doc, visited, test)
+	}
+	u.walk(schema, typeName, set, doc, visited, test)
+}
+
+// returnedTypes return name of every object type reachable from the return type of operations, sorted by name
+// eg. getProduct: Product and Product { seller: Seller } return Product and Seller
+func returnedTypes(schema *Schema, operations []*Operation) []string {
+	var queue []string
+	seen := make(map[string]bool)
+	visit := func(t string) {
+		if _, ok := schema.Types[t]; ok && !seen[t] {
+			seen[t] = true
+			queue = append(queue, t)
+		}
+	}
+	for _, op := range operations {
+		visit(NamedType(op.Type))
+	}
+	for i := 0; i < len(queue); i++ {
+		for _, field := range schema.Types[queue[i]].Fields {
+			visit(NamedType(field.Type))
+		}
+	}
+	sort.Strings(queue)
+	return queue
+}
+
+// writeFieldSheet will list every field of every type returned by operations and the test case that select it
+// field that is never selected is marked No TestCase, no test can assert on its value
+func writeFieldSheet(xlsx *excelize.File, schema *Schema, operations []*Operation, usage fieldUsage, status []string) {
+	xlsx.NewSheet(fieldSheetName)
+	sheet := fieldSheetName
+
+	// create column name
+	xlsx.SetCellValue(sheet, "A1", "Type")
+	xlsx.SetCellValue(sheet, "B1", "Field")
+	xlsx.SetCellValue(sheet, "C1", "Field Type")
+	xlsx.SetCellValue(sheet, "D1", "Tested")
+	xlsx.SetCellValue(sheet, "E1", "Test Case Name")
+	xlsx.SetCellValue(sheet, "F1", "Status")
+	xlsx.SetCellValue(sheet, "G1", "Notes")
+	xlsx.AutoFilter(sheet, "A1", "G1", "")
+
+	// add data validation for status column
+	dvRange := excelize.NewDataValidation(true)
+	dvRange.Sqref = "F:F"
+	dvRange.SetDropList(status)
+	xlsx.AddDataValidation(sheet, dvRange)
+
+	row := 1
+	for _, typeName := range returnedTypes(schema, operations) {
+		obj := schema.Types[typeName]
+		if len(obj.Fields) == 0 {
+			continue
+		}
+		start := row + 1
+		for _, field := range obj.Fields {
+			row++
+			tests := usage[typeName][field.Name]
+			xlsx.SetCellValue(sheet, fmt.Sprintf("A%d", row), typeName)
+			xlsx.SetCellValue(sheet, fmt.Sprintf("B%d", row), field.Name)
+			xlsx.SetCellValue(sheet, fmt.Sprintf("C%d", row), field.Type)
+			xlsx.SetCellValue(sheet, fmt.Sprintf("D%d", row), yesNo(len(tests) > 0))
+			xlsx.SetCellValue(sheet, fmt.Sprintf("E%d", row), strings.Join(tests, ", "))
+			switch {
+			case len(tests) > 0:
+				xlsx.SetCellValue(sheet, fmt.Sprintf("F%d", row), "Live")
+			case field.Deprecated:
+				xlsx.SetCellValue(sheet, fmt.Sprintf("F%d", row), "Wont Do")
+			default:
+				xlsx.SetCellValue(sheet, fmt.Sprintf("F%d", row), "No TestCase")
+			}
+			if field.Deprecated {
+				xlsx.SetCellValue(sheet, fmt.Sprintf("G%d", row), "Deprecated")
+			}
+		}
+
+		// merge cell of the same type
+		xlsx.MergeCell(sheet, "A"+strconv.Itoa(start), "A"+strconv.Itoa(row))
+	}
+}
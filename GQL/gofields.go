@@ -0,0 +1,392 @@
+package gql
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// import path of graphql-go
+const graphqlGoPath = "github.com/graphql-go/graphql"
+
+// LoadGoFields will read operation of a schema built with github.com/graphql-go/graphql in go file path
+// every key of graphql.Fields is an operation, root is taken from the enclosing
+// graphql.NewObject(graphql.ObjectConfig{Name: "Query", ...}) or graphql.SchemaConfig{Query: queryType},
+// otherwise graphql.Fields belong to defaultRoot (eg. queries.go is Query), empty defaultRoot skip it
+func LoadGoFields(path string, defaultRoot string) (*Schema, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	e := &fieldsExtractor{
+		fset:      fset,
+		consts:    make(map[string]ast.Expr),
+		vars:      make(map[string]ast.Expr),
+		roots:     make(map[string]string),
+		typeNames: make(map[string]string),
+		funcs:     make(map[string]*ast.FuncDecl),
+		pkgNames:  make(map[string]bool),
+		handled:   make(map[*ast.CompositeLit]bool),
+		schema:    &Schema{},
+	}
+	// type and root variable can be declared in other file of the package, eg. productType in types.go
+	names, err := filepath.Glob(filepath.Join(filepath.Dir(path), "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") || filepath.Clean(name) == filepath.Clean(path) {
+			continue
+		}
+		other, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil || other.Name.Name != file.Name.Name {
+			continue
+		}
+		e.collect(other)
+	}
+	e.collect(file)
+
+	// graphql.Fields of an object, field of root object is an operation
+	// field of other object (eg. Product) is only used for field coverage
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok || (!e.isGraphqlType(lit.Type, "ObjectConfig") && !e.isGraphqlType(lit.Type, "InterfaceConfig")) {
+			return true
+		}
+		name, _ := e.stringValue(keyValue(lit, "Name"))
+		root := e.rootOf(lit, name)
+		if fields := keyValue(lit, "Fields"); fields != nil {
+			for _, fieldsLit := range e.fieldsLiterals(fields) {
+				e.handled[fieldsLit] = true
+				if root != "" {
+					e.addFields(root, fieldsLit)
+				} else if name != "" {
+					e.addObjectFields(name, fieldsLit)
+				}
+			}
+		}
+		return true
+	})
+
+	// graphql.Fields outside of an object, eg. func ProductQueries() graphql.Fields { ... }
+	if defaultRoot != "" {
+		ast.Inspect(file, func(n ast.Node) bool {
+			lit, ok := n.(*ast.CompositeLit)
+			if ok && e.isGraphqlType(lit.Type, "Fields") && !e.handled[lit] {
+				e.addFields(defaultRoot, lit)
+			}
+			return true
+		})
+	}
+	return e.schema, nil
+}
+
+// fieldsExtractor read graphql-go schema from one go file of a package
+type fieldsExtractor struct {
+	fset      *token.FileSet
+	consts    map[string]ast.Expr        // constant declared in the package, keyed by name
+	vars      map[string]ast.Expr        // variable declared in the package, keyed by name
+	roots     map[string]string          // variable used as root in graphql.SchemaConfig, eg. queryType -> Query
+	typeNames map[string]string          // variable holding a graphql type, eg. productType -> Product
+	funcs     map[string]*ast.FuncDecl   // function declared in the package, keyed by name
+	pkgNames  map[string]bool            // name github.com/graphql-go/graphql is imported as, eg. graphql
+	handled   map[*ast.CompositeLit]bool // graphql.Fields that belong to an object
+	schema    *Schema
+}
+
+// collect will record every constant, variable, function and root variable in file
+func (e *fieldsExtractor) collect(file *ast.File) {
+	for _, spec := range file.Imports {
+		if path, _ := strconv.Unquote(spec.Path.Value); path == graphqlGoPath {
+			name := "graphql"
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			e.pkgNames[name] = true
+		}
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Recv == nil && n.Body != nil {
+				e.funcs[n.Name.Name] = n
+			}
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if i >= len(n.Values) {
+					continue
+				}
+				e.vars[name.Name] = n.Values[i]
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			for i, lhs := range n.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					e.vars[ident.Name] = n.Rhs[i]
+				}
+			}
+		case *ast.GenDecl:
+			if n.Tok != token.CONST {
+				return true
+			}
+			for _, spec := range n.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if i < len(vs.Values) {
+						e.consts[name.Name] = vs.Values[i]
+					}
+				}
+			}
+		case *ast.CompositeLit:
+			// graphql.SchemaConfig{Query: queryType, Mutation: mutationType}
+			if !e.isGraphqlType(n.Type, "SchemaConfig") {
+				return true
+			}
+			for _, root := range []string{Query, Mutation, Subscription} {
+				if ident, ok := keyValue(n, root).(*ast.Ident); ok {
+					e.roots[ident.Name] = root
+				}
+			}
+		}
+		return true
+	})
+
+	// type name of variable holding graphql.NewObject, graphql.NewEnum, etc
+	for name, value := range e.vars {
+		if config := newTypeConfig(value); config != nil {
+			if typeName, ok := e.stringValue(keyValue(config, "Name")); ok {
+				e.typeNames[name] = typeName
+			}
+		}
+	}
+}
+
+// rootOf return root of object config lit named name
+// root from graphql.SchemaConfig is used first, then the name of the object
+func (e *fieldsExtractor) rootOf(lit *ast.CompositeLit, name string) string {
+	for variable, value := range e.vars {
+		if newTypeConfig(value) == lit {
+			if root, ok := e.roots[variable]; ok {
+				return root
+			}
+		}
+	}
+	switch name {
+	case Query, Mutation, Subscription:
+		return name
+	}
+	return ""
+}
+
+// fieldsLiterals return every graphql.Fields literal in expr
+// eg. graphql.Fields{...}, variable holding it, graphql.FieldsThunk(func() graphql.Fields { return graphql.Fields{...} })
+// or a call of function in the package returning it, eg. Fields: productFields()
+func (e *fieldsExtractor) fieldsLiterals(expr ast.Expr) []*ast.CompositeLit {
+	var node ast.Node = expr
+	switch v := expr.(type) {
+	case *ast.Ident:
+		if value, ok := e.vars[v.Name]; ok {
+			node = value
+		}
+	case *ast.CallExpr:
+		if ident, ok := v.Fun.(*ast.Ident); ok && e.funcs[ident.Name] != nil {
+			node = e.funcs[ident.Name].Body
+		}
+	}
+	var found []*ast.CompositeLit
+	ast.Inspect(node, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.CompositeLit); ok && e.isGraphqlType(lit.Type, "Fields") {
+			found = append(found, lit)
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// addFields will add every field in graphql.Fields literal as operation of root
+func (e *fieldsExtractor) addFields(root string, lit *ast.CompositeLit) {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		name, ok := e.stringValue(kv.Key)
+		if !ok {
+			continue
+		}
+		op := &Operation{
+			Root: root,
+			Name: name,
+			Pos:  e.fset.Position(kv.Key.Pos()),
+		}
+		if field := compositeOf(kv.Value); field != nil {
+			op.Type = e.typeString(keyValue(field, "Type"))
+			if reason, ok := e.stringValue(keyValue(field, "DeprecationReason")); ok && reason != "" {
+				op.Deprecated = true
+				op.DeprecationReason = reason
+			}
+			if args := compositeOf(keyValue(field, "Args")); args != nil {
+				op.Args = e.arguments(args)
+			}
+		}
+		e.schema.add(op)
+	}
+}
+
+// addObjectFields will add every field in graphql.Fields literal as field of object typeName
+func (e *fieldsExtractor) addObjectFields(typeName string, lit *ast.CompositeLit) {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		name, ok := e.stringValue(kv.Key)
+		if !ok {
+			continue
+		}
+		field := Field{Name: name}
+		if config := compositeOf(kv.Value); config != nil {
+			field.Type = e.typeString(keyValue(config, "Type"))
+			reason, _ := e.stringValue(keyValue(config, "DeprecationReason"))
+			field.Deprecated = reason != ""
+		}
+		e.schema.addField(typeName, field)
+	}
+}
+
+// arguments return every argument in graphql.FieldConfigArgument literal
+func (e *fieldsExtractor) arguments(lit *ast.CompositeLit) []Argument {
+	var args []Argument
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		name, ok := e.stringValue(kv.Key)
+		if !ok {
+			continue
+		}
+		arg := Argument{Name: name}
+		if config := compositeOf(kv.Value); config != nil {
+			arg.Type = e.typeString(keyValue(config, "Type"))
+			if value := keyValue(config, "DefaultValue"); value != nil {
+				arg.Default = types.ExprString(value)
+			}
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+// typeString return graphql-go type expression in SDL notation
+// eg. graphql.NewNonNull(graphql.NewList(productType)) -> [Product]!
+func (e *fieldsExtractor) typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case nil:
+		return ""
+	case *ast.CallExpr:
+		if sel, ok := t.Fun.(*ast.SelectorExpr); ok && len(t.Args) == 1 {
+			switch sel.Sel.Name {
+			case "NewNonNull":
+				return e.typeString(t.Args[0]) + "!"
+			case "NewList":
+				return "[" + e.typeString(t.Args[0]) + "]"
+			}
+		}
+		// inline type, eg. graphql.NewObject(graphql.ObjectConfig{Name: "Product"})
+		if config := newTypeConfig(t); config != nil {
+			if name, ok := e.stringValue(keyValue(config, "Name")); ok {
+				return name
+			}
+		}
+	case *ast.SelectorExpr:
+		// built in scalar, eg. graphql.String
+		if ident, ok := t.X.(*ast.Ident); ok && e.pkgNames[ident.Name] {
+			return t.Sel.Name
+		}
+	case *ast.Ident:
+		if name, ok := e.typeNames[t.Name]; ok {
+			return name
+		}
+	}
+	return types.ExprString(expr)
+}
+
+// stringValue will resolve string literal and constant
+func (e *fieldsExtractor) stringValue(expr ast.Expr) (string, bool) {
+	switch v := expr.(type) {
+	case *ast.BasicLit:
+		if v.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(v.Value)
+		return value, err == nil
+	case *ast.Ident:
+		if value, ok := e.consts[v.Name].(*ast.BasicLit); ok {
+			return e.stringValue(value)
+		}
+	}
+	return "", false
+}
+
+// isGraphqlType will check if expr is graphql.<name> of github.com/graphql-go/graphql, eg. graphql.Fields
+// map of other package with the same name is not a schema, eg. logrus.Fields
+func (e *fieldsExtractor) isGraphqlType(expr ast.Expr, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && e.pkgNames[ident.Name]
+}
+
+// newTypeConfig return config of graphql.NewObject(graphql.ObjectConfig{...}) and other type constructor
+func newTypeConfig(expr ast.Expr) *ast.CompositeLit {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	switch sel.Sel.Name {
+	case "NewObject", "NewInterface", "NewEnum", "NewInputObject", "NewScalar", "NewUnion":
+		return compositeOf(call.Args[0])
+	}
+	return nil
+}
+
+// compositeOf return composite literal of expr, eg. &graphql.Field{...} -> graphql.Field{...}
+func compositeOf(expr ast.Expr) *ast.CompositeLit {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	lit, _ := expr.(*ast.CompositeLit)
+	return lit
+}
+
+// keyValue return value of key in struct literal, nil if not set
+func keyValue(lit *ast.CompositeLit, key string) ast.Expr {
+	if lit == nil {
+		return nil
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if ident, ok := kv.Key.(*ast.Ident); ok && ident.Name == key {
+			return kv.Value
+		}
+	}
+	return nil
+}
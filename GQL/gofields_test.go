@@ -0,0 +1,196 @@
+package gql
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestLoadGoFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		defaultRoot string
+		want        []string // operation as "Root signature"
+		wantFields  []string // field of other object as "Type.field: type"
+	}{
+		{
+			name: "nested graphql.Fields literal",
+			src: `package graph
+
+import "github.com/graphql-go/graphql"
+
+var queryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Query",
+	Fields: graphql.Fields{
+		"getProduct": &graphql.Field{
+			Type: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Product",
+				Fields: graphql.Fields{
+					"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+					"name": &graphql.Field{Type: graphql.String, DeprecationReason: "use title"},
+				},
+			}),
+			Args: graphql.FieldConfigArgument{
+				"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+			},
+		},
+	},
+})
+`,
+			defaultRoot: Query,
+			want:        []string{"Query getProduct(id: Int!): Product"},
+			wantFields:  []string{"Product.id: ID!", "Product.name: String deprecated"},
+		},
+		{
+			name: "fields built in helper func",
+			src: `package graph
+
+import gql "github.com/graphql-go/graphql"
+
+var schemaConfig = gql.SchemaConfig{Query: rootQuery, Mutation: rootMutation}
+
+var rootQuery = gql.NewObject(gql.ObjectConfig{
+	Name:   "RootQuery",
+	Fields: gql.FieldsThunk(func() gql.Fields { return queryFields() }),
+})
+
+var rootMutation = gql.NewObject(gql.ObjectConfig{
+	Name:   "RootMutation",
+	Fields: mutationFields(),
+})
+
+func queryFields() gql.Fields {
+	return gql.Fields{
+		"listProduct": &gql.Field{Type: gql.NewList(gql.String)},
+	}
+}
+
+func mutationFields() gql.Fields {
+	fields := gql.Fields{
+		"addCart": &gql.Field{Type: gql.Boolean},
+	}
+	return fields
+}
+
+// ProductQueries is merged into the root by the caller
+func ProductQueries() gql.Fields {
+	return gql.Fields{"getProduct": &gql.Field{Type: gql.Int}}
+}
+`,
+			defaultRoot: Query,
+			want: []string{
+				"Mutation addCart: Boolean",
+				"Query getProduct: Int",
+				"Query listProduct: [String]",
+			},
+		},
+		{
+			name: "non schema map is ignored",
+			src: `package graph
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/sirupsen/logrus"
+)
+
+type Fields map[string]interface{}
+
+var labels = map[string]string{"getLabel": "label"}
+
+func resolve(p graphql.ResolveParams) (interface{}, error) {
+	logrus.WithFields(logrus.Fields{"getUser": p.Args["id"]}).Info("resolve")
+	_ = Fields{"getLocal": 1}
+	return map[string]interface{}{"getResult": 1}, nil
+}
+
+var mutationType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Mutation",
+	Fields: graphql.Fields{
+		"deleteCart": &graphql.Field{
+			Type:    graphql.Boolean,
+			Resolve: resolve,
+			Args:    graphql.FieldConfigArgument{"cartId": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0}},
+		},
+	},
+})
+`,
+			defaultRoot: Query,
+			want:        []string{"Mutation deleteCart(cartId: Int = 0): Boolean"},
+		},
+		{
+			name: "no default root",
+			src: `package graph
+
+import "github.com/graphql-go/graphql"
+
+func ProductQueries() graphql.Fields {
+	return graphql.Fields{"getProduct": &graphql.Field{Type: graphql.Int}}
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{"schema.go": tt.src})
+			schema, err := LoadGoFields(filepath.Join(dir, "schema.go"), tt.defaultRoot)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := operations(schema); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("operations\ngot  %q\nwant %q", got, tt.want)
+			}
+			var fields []string
+			for _, obj := range schema.Types {
+				for _, f := range obj.Fields {
+					field := obj.Name + "." + f.Name + ": " + f.Type
+					if f.Deprecated {
+						field += " deprecated"
+					}
+					fields = append(fields, field)
+				}
+			}
+			sort.Strings(fields)
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("fields\ngot  %q\nwant %q", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestLoadGoFieldsOtherFile(t *testing.T) {
+	// type and root variable declared in other file of the package
+	dir := writeFiles(t, map[string]string{
+		"types.go": `package graph
+
+import "github.com/graphql-go/graphql"
+
+var productType = graphql.NewObject(graphql.ObjectConfig{Name: "Product"})
+
+var schemaConfig = graphql.SchemaConfig{Subscription: subscriptionType}
+`,
+		"subscription.go": `package graph
+
+import "github.com/graphql-go/graphql"
+
+var subscriptionType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RootSubscription",
+	Fields: graphql.Fields{
+		"productUpdated": &graphql.Field{Type: graphql.NewNonNull(productType)},
+	},
+})
+`,
+		"other_test.go": "package graph_test\n",
+	})
+	schema, err := LoadGoFields(filepath.Join(dir, "subscription.go"), Query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := operations(schema), []string{"Subscription productUpdated: Product!"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("operations = %q, want %q", got, want)
+	}
+	if pos := schema.Operations[0].Pos; pos.Line != 8 || pos.Column != 3 {
+		t.Errorf("productUpdated position = %v, want line 8 column 3", pos)
+	}
+}
@@ -0,0 +1,253 @@
+// Package gql lists graphql queries/mutation/subscription of a service and its integration test into ITSWEEP sheet
+package gql
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+
+	"github.com/IndraWirananta/IntegrationTestRelatedScript/testcase"
+)
+
+// notFoundEndpoint is the endpoint name of a test that select no query, mutation or subscription of the schema
+// the text is kept from when only queries and mutation file was read, so existing sheet stay the same
+const notFoundEndpoint = "Not found in queries/mutation file"
+
+// Config hold every path needed by gql sweep
+type Config struct {
+	IntegrationPath  string   `yaml:"tests"`         // gql integration test local path
+	SchemaPath       string   `yaml:"schema"`        // graphql schema file or directory (.graphql, .graphqls), or introspection result (.json)
+	QueriesPath      string   `yaml:"queries"`       // local path for app queries
+	MutationPath     string   `yaml:"mutations"`     // local path for app mutation
+	SubscriptionPath string   `yaml:"subscriptions"` // local path for app subscription
+	DocumentName     string   `yaml:"output"`        // file name for sheet
+	Envs             []string `yaml:"envs"`          // env to report, the first env a test has is used
+	Status           []string `yaml:"status"`        // dropdown value of status column
+}
+
+// Run will list every queries, mutation and subscription along with its integration test
+// and save it as excel sheet in DocumentName
+func Run(cfg Config) error {
+	integrationPath := cfg.IntegrationPath
+	documentName := cfg.DocumentName
+
+	// create new excel sheet
+	xlsx := excelize.NewFile()
+	sheet1Name := "Sheet1"
+	xlsx.SetSheetName(xlsx.GetSheetName(1), sheet1Name)
+
+	// create column name
+	xlsx.SetCellValue(sheet1Name, "A1", "Endpoint")
+	xlsx.SetCellValue(sheet1Name, "B1", "Type")
+	xlsx.SetCellValue(sheet1Name, "C1", "Test Case Name")
+	xlsx.SetCellValue(sheet1Name, "D1", "File Name")
+	xlsx.SetCellValue(sheet1Name, "E1", "Scenario")
+	xlsx.SetCellValue(sheet1Name, "F1", "Expected Response")
+	xlsx.SetCellValue(sheet1Name, "G1", "Request Param")
+	xlsx.SetCellValue(sheet1Name, "H1", "Query")
+	xlsx.SetCellValue(sheet1Name, "I1", "Status")
+	xlsx.SetCellValue(sheet1Name, "J1", "Notes")
+	xlsx.SetCellValue(sheet1Name, "K1", "PIC")
+
+	// add auto filter to column
+	err := xlsx.AutoFilter(sheet1Name, "A1", "K1", "")
+	if err != nil {
+		return err
+	}
+
+	// add data validation for status column
+	dvRange := excelize.NewDataValidation(true)
+	dvRange.Sqref = "I:I"
+	dvRange.SetDropList(cfg.Status)
+	xlsx.AddDataValidation(sheet1Name, dvRange)
+
+	// parse every operation declared in the schema, queries, mutation and subscription file
+	schema, err := loadSchema(cfg)
+	if err != nil {
+		return err
+	}
+	operations := schema.Operations
+	byKey := make(map[string]*Operation) // operation keyed by root and name, eg. Query.getProduct
+	for _, op := range operations {
+		byKey[op.Root+"."+op.Name] = op
+	}
+	tested := make(map[*Operation]bool) // operation that has integration test
+	usage := make(argumentUsage)        // test case that supply each argument
+	fieldsUsage := make(fieldUsage)     // test case that select each output field
+
+	var total int // total integration test
+
+	// used to merge column for the same endpoint
+	var prevValue string
+	var mergeCellStart int
+	var mergeCellEnd int
+
+	err = testcase.Walk(integrationPath, // will "walk" to every directory and subdirectory in integrationPath
+		func(path string, result *testcase.IntegrationTest, err error) error {
+			if err != nil {
+				fmt.Println(err)
+				return nil
+			}
+
+			// only report the first env in cfg.Envs that the test has
+			structure, ok := result.First(cfg.Envs)
+			if !ok {
+				fmt.Printf("skip %s: no structure for env %v\n", path, cfg.Envs)
+				return nil
+			}
+			total++
+
+			variables := structure.VariablesString()
+
+			// credit every operation selected by the query, including field inside fragment and aliased field
+			selections, err := Selections(result.Query)
+			if err != nil {
+				fmt.Println(path, err)
+			}
+			var names, sheetTypes []string
+			testVariables, err := structure.VariablesMap()
+			if err != nil {
+				fmt.Println(path, err)
+			}
+			for _, selection := range selections {
+				op, ok := byKey[selection.Root+"."+selection.Field.Name]
+				if !ok {
+					continue
+				}
+				tested[op] = true
+				usage.add(op, suppliedArgs(selection, testVariables), result.QueryName)
+				fieldsUsage.add(schema, op, selection, result.QueryName)
+				names = appendUnique(names, op.Name)
+				sheetTypes = appendUnique(sheetTypes, op.SheetType())
+			}
+			endpointName := strings.Join(names, ", ")
+			if endpointName != "" {
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("B%d", total+1), strings.Join(sheetTypes, ", "))
+			}
+			// if not found in query, mutation or subscription, then must be part of chain test case outside of scope
+			// will insert endpointName as notFoundEndpoint
+			if endpointName == "" {
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("B%d", total+1), "-")
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("J%d", total+1), "Part of chain test case")
+				endpointName = notFoundEndpoint
+			}
+
+			// insert data into sheet
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("A%d", total+1), endpointName)
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("C%d", total+1), result.QueryName)
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("D%d", total+1), result.FileName())
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("F%d", total+1), structure.ResponseCode)
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("G%d", total+1), variables)
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("H%d", total+1), result.Query)
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("I%d", total+1), "Live")
+
+			// merge cell if same endpoint
+			if prevValue == endpointName && prevValue != notFoundEndpoint {
+				mergeCellEnd += 1
+			} else {
+				xlsx.MergeCell(sheet1Name, "A"+strconv.Itoa(mergeCellStart), "A"+strconv.Itoa(mergeCellEnd))
+				xlsx.MergeCell(sheet1Name, "B"+strconv.Itoa(mergeCellStart), "B"+strconv.Itoa(mergeCellEnd))
+				mergeCellStart = total + 1
+				mergeCellEnd = mergeCellStart
+			}
+			prevValue = endpointName
+
+			return nil
+		})
+	if err != nil {
+		log.Println(err)
+	}
+
+	// sort operation without integration test by name
+	var untested []*Operation
+	for _, op := range operations {
+		if !tested[op] {
+			untested = append(untested, op)
+		}
+	}
+	sort.SliceStable(untested, func(i, j int) bool {
+		return untested[i].Name < untested[j].Name
+	})
+
+	fmt.Println("Got a total of " + strconv.Itoa(total) + " testcases")
+
+	// insert the rest of operation (endpoint without integration test)
+	for _, op := range untested {
+		total++
+		xlsx.SetCellValue(sheet1Name, fmt.Sprintf("A%d", total+1), op.Name)
+		xlsx.SetCellValue(sheet1Name, fmt.Sprintf("B%d", total+1), op.SheetType())
+		if op.Deprecated {
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("I%d", total+1), "Wont Do")
+		} else {
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("I%d", total+1), "No TestCase")
+		}
+		xlsx.SetCellValue(sheet1Name, fmt.Sprintf("J%d", total+1), op.Note())
+	}
+
+	fmt.Println("Scanned a total of " + strconv.Itoa(len(operations)) + " endpoint")
+
+	// list every argument and the test case that supply it
+	writeArgumentSheet(xlsx, operations, usage, cfg.Status)
+
+	// list every output field of returned type and the test case that select it
+	writeFieldSheet(xlsx, schema, operations, fieldsUsage, cfg.Status)
+
+	// save created sheet
+	return xlsx.SaveAs(documentName)
+}
+
+// loadSchema will parse and merge every schema source in cfg
+// operation in graphql.Fields of queries file is Query, of mutation file is Mutation and of subscription file is Subscription
+func loadSchema(cfg Config) (*Schema, error) {
+	schema := &Schema{}
+	for _, source := range []struct{ path, root string }{
+		{cfg.SchemaPath, ""},
+		{cfg.QueriesPath, Query},
+		{cfg.MutationPath, Mutation},
+		{cfg.SubscriptionPath, Subscription},
+	} {
+		if source.path == "" {
+			continue
+		}
+
+		// introspection query result, eg. schema.json
+		if filepath.Ext(source.path) == ".json" {
+			found, err := LoadIntrospection(source.path)
+			if err != nil {
+				return nil, err
+			}
+			schema.Merge(found)
+			continue
+		}
+
+		found, err := LoadSDL(source.path)
+		if err != nil {
+			return nil, err
+		}
+		schema.Merge(found)
+
+		if filepath.Ext(source.path) == ".go" {
+			found, err := LoadGoFields(source.path, source.root)
+			if err != nil {
+				return nil, err
+			}
+			schema.Merge(found)
+		}
+	}
+	return schema, nil
+}
+
+// appendUnique will append value to list if it is not in list yet
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
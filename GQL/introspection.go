@@ -0,0 +1,128 @@
+package gql
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// introspection is the result of the introspection query, with or without the data wrapper
+type introspection struct {
+	Data struct {
+		Schema *introspectionSchema `json:"__schema"`
+	} `json:"data"`
+	Schema *introspectionSchema `json:"__schema"`
+}
+
+type introspectionSchema struct {
+	QueryType        *introspectionName  `json:"queryType"`
+	MutationType     *introspectionName  `json:"mutationType"`
+	SubscriptionType *introspectionName  `json:"subscriptionType"`
+	Types            []introspectionType `json:"types"`
+}
+
+type introspectionName struct {
+	Name string `json:"name"`
+}
+
+type introspectionType struct {
+	Kind   string               `json:"kind"`
+	Name   string               `json:"name"`
+	Fields []introspectionField `json:"fields"`
+}
+
+type introspectionField struct {
+	Name              string                  `json:"name"`
+	Args              []introspectionArgument `json:"args"`
+	Type              introspectionTypeRef    `json:"type"`
+	IsDeprecated      bool                    `json:"isDeprecated"`
+	DeprecationReason string                  `json:"deprecationReason"`
+}
+
+type introspectionArgument struct {
+	Name         string               `json:"name"`
+	Type         introspectionTypeRef `json:"type"`
+	DefaultValue *string              `json:"defaultValue"`
+}
+
+type introspectionTypeRef struct {
+	Kind   string                `json:"kind"`
+	Name   string                `json:"name"`
+	OfType *introspectionTypeRef `json:"ofType"`
+}
+
+// String return type in SDL notation, eg. {kind: NON_NULL, ofType: {kind: SCALAR, name: Int}} -> Int!
+func (t introspectionTypeRef) String() string {
+	if t.OfType == nil {
+		return t.Name
+	}
+	switch t.Kind {
+	case "NON_NULL":
+		return t.OfType.String() + "!"
+	case "LIST":
+		return "[" + t.OfType.String() + "]"
+	}
+	return t.Name
+}
+
+// LoadIntrospection will read operation from introspection query result in path, eg. schema.json
+// both {"data": {"__schema": ...}} and {"__schema": ...} is accepted
+func LoadIntrospection(path string) (*Schema, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var result introspection
+	if err := json.Unmarshal(content, &result); err != nil {
+		return nil, &os.PathError{Op: "parse", Path: path, Err: err}
+	}
+	s := result.Schema
+	if s == nil {
+		s = result.Data.Schema
+	}
+	if s == nil {
+		return nil, &os.PathError{Op: "parse", Path: path, Err: fmt.Errorf("missing __schema, not an introspection result")}
+	}
+
+	roots := make(map[string]string)
+	for root, t := range map[string]*introspectionName{Query: s.QueryType, Mutation: s.MutationType, Subscription: s.SubscriptionType} {
+		if t != nil {
+			roots[t.Name] = root
+		}
+	}
+
+	schema := &Schema{}
+	for _, t := range s.Types {
+		// skip introspection type, eg. __Schema
+		if (t.Kind != "OBJECT" && t.Kind != "INTERFACE") || strings.HasPrefix(t.Name, "__") {
+			continue
+		}
+		root, ok := roots[t.Name]
+		if !ok {
+			for _, field := range t.Fields {
+				schema.addField(t.Name, Field{Name: field.Name, Type: field.Type.String(), Deprecated: field.IsDeprecated})
+			}
+			continue
+		}
+		for _, field := range t.Fields {
+			op := &Operation{
+				Root:              root,
+				Name:              field.Name,
+				Type:              field.Type.String(),
+				Deprecated:        field.IsDeprecated,
+				DeprecationReason: field.DeprecationReason,
+			}
+			for _, arg := range field.Args {
+				a := Argument{Name: arg.Name, Type: arg.Type.String()}
+				if arg.DefaultValue != nil {
+					a.Default = *arg.DefaultValue
+				}
+				op.Args = append(op.Args, a)
+			}
+			schema.add(op)
+		}
+	}
+	return schema, nil
+}
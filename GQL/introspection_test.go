@@ -0,0 +1,59 @@
+package gql
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestLoadIntrospection(t *testing.T) {
+	want := []*Operation{
+		{Root: Query, Name: "getProduct", Type: "Product", Args: []Argument{
+			{Name: "id", Type: "Int!"},
+			{Name: "lang", Type: "String", Default: `"en"`},
+		}},
+		{Root: Query, Name: "listProduct", Type: "[Product!]!", Deprecated: true, DeprecationReason: "use searchProduct"},
+		{Root: Mutation, Name: "addCart", Type: "Boolean!", Args: []Argument{
+			{Name: "productId", Type: "Int!"},
+			{Name: "quantity", Type: "Int", Default: "1"},
+		}},
+	}
+	// Subscription is not the subscriptionType, so it is an object like Product
+	wantTypes := []string{"Product", "Subscription"}
+
+	for _, file := range []string{"introspection_data.json", "introspection_schema.json"} {
+		t.Run(file, func(t *testing.T) {
+			schema, err := LoadIntrospection(filepath.Join("testdata", file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(schema.Operations, want) {
+				for _, op := range schema.Operations {
+					t.Logf("got %+v", *op)
+				}
+				t.Errorf("operations differ")
+			}
+			var types []string
+			for name := range schema.Types {
+				types = append(types, name)
+			}
+			sort.Strings(types)
+			if !reflect.DeepEqual(types, wantTypes) {
+				t.Errorf("types = %v, want %v", types, wantTypes)
+			}
+		})
+	}
+}
+
+func TestLoadIntrospectionInvalid(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"empty.json":   `{"data": {}}`,
+		"invalid.json": `{"__schema": `,
+	})
+	for _, name := range []string{"empty.json", "invalid.json", "missing.json"} {
+		if _, err := LoadIntrospection(filepath.Join(dir, name)); err == nil {
+			t.Errorf("LoadIntrospection(%s) should fail", name)
+		}
+	}
+}
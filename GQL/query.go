@@ -0,0 +1,63 @@
+package gql
+
+import (
+	gqlast "github.com/vektah/gqlparser/v2/ast"
+	gqlparser "github.com/vektah/gqlparser/v2/parser"
+)
+
+// Selection is a root field selected by a test query
+// eg. getProduct in query q($id: Int!) { p: getProduct(id: $id) { ...productFields } }
+type Selection struct {
+	Root      string                      // Query, Mutation or Subscription
+	Field     *gqlast.Field               // selected field, with its alias, arguments and selection set
+	Operation *gqlast.OperationDefinition // operation the field is selected in
+	Document  *gqlast.QueryDocument       // used to resolve fragment spread inside the field
+}
+
+// Selections will parse query and return every root field it select
+// field inside fragment spread and inline fragment is included, field name is used instead of its alias
+func Selections(query string) ([]Selection, error) {
+	doc, err := gqlparser.ParseQuery(&gqlast.Source{Name: "query", Input: query})
+	if err != nil {
+		return nil, err
+	}
+
+	roots := map[gqlast.Operation]string{gqlast.Query: Query, gqlast.Mutation: Mutation, gqlast.Subscription: Subscription}
+	var selections []Selection
+	for _, op := range doc.Operations {
+		root, ok := roots[op.Operation]
+		if !ok { // query shorthand, eg. { getProduct(id: 1) { id } }
+			root = Query
+		}
+		for _, field := range fields(doc, op.SelectionSet, make(map[string]bool)) {
+			if field.Name == "__typename" {
+				continue
+			}
+			selections = append(selections, Selection{Root: root, Field: field, Operation: op, Document: doc})
+		}
+	}
+	return selections, nil
+}
+
+// fields return every field in set, field inside fragment is included
+// visited is fragment already expanded, used to stop recursive fragment
+func fields(doc *gqlast.QueryDocument, set gqlast.SelectionSet, visited map[string]bool) []*gqlast.Field {
+	var found []*gqlast.Field
+	for _, selection := range set {
+		switch s := selection.(type) {
+		case *gqlast.Field:
+			found = append(found, s)
+		case *gqlast.InlineFragment:
+			found = append(found, fields(doc, s.SelectionSet, visited)...)
+		case *gqlast.FragmentSpread:
+			fragment := doc.Fragments.ForName(s.Name)
+			if fragment == nil || visited[s.Name] {
+				continue
+			}
+			visited[s.Name] = true
+			found = append(found, fields(doc, fragment.SelectionSet, visited)...)
+			delete(visited, s.Name)
+		}
+	}
+	return found
+}
@@ -0,0 +1,97 @@
+package gql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSelections(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string // selection as "Root field operation", operation is empty for anonymous operation
+	}{
+		{
+			name:  "query shorthand",
+			query: `{ getProduct(id: 1) { id } __typename }`,
+			want:  []string{"Query getProduct "},
+		},
+		{
+			name:  "aliased field",
+			query: `query q($id: Int!) { p: getProduct(id: $id) { id } other: getProduct(id: 2) { id } }`,
+			want:  []string{"Query getProduct q", "Query getProduct q"},
+		},
+		{
+			name: "named fragment spread",
+			query: `query q { ...rootFields }
+
+fragment rootFields on Query {
+  getProduct(id: 1) { id }
+  ...moreFields
+}
+
+fragment moreFields on Query {
+  listProduct { id }
+  ...rootFields
+}
+
+fragment unused on Query {
+  getCart { id }
+}`,
+			want: []string{"Query getProduct q", "Query listProduct q"},
+		},
+		{
+			name: "inline fragment",
+			query: `mutation m {
+  ... on Mutation {
+    addCart(productId: 1)
+    ... @include(if: true) { deleteCart(cartId: 1) }
+  }
+}`,
+			want: []string{"Mutation addCart m", "Mutation deleteCart m"},
+		},
+		{
+			name: "every operation of multi operation document",
+			query: `query getItem { getProduct(id: 1) { id } }
+# mutation commented { removeCart }
+mutation addItem { addCart(productId: 1) }
+subscription watch { cartUpdated }`,
+			want: []string{
+				"Query getProduct getItem",
+				"Mutation addCart addItem",
+				"Subscription cartUpdated watch",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selections, err := Selections(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, s := range selections {
+				got = append(got, s.Root+" "+s.Field.Name+" "+s.Operation.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selections\ngot  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectionsAlias(t *testing.T) {
+	selections, err := Selections(`{ p: getProduct(id: 1) { id } }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selections) != 1 || selections[0].Field.Alias != "p" || selections[0].Field.Name != "getProduct" {
+		t.Errorf("selections = %+v", selections)
+	}
+}
+
+func TestSelectionsInvalid(t *testing.T) {
+	if _, err := Selections(`query { getProduct(id: 1) { id }`); err == nil {
+		t.Error("Selections should fail on unclosed selection set")
+	}
+}
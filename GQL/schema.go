@@ -0,0 +1,155 @@
+package gql
+
+import (
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"strings"
+)
+
+// root operation type of a graphql schema
+const (
+	Query        = "Query"
+	Mutation     = "Mutation"
+	Subscription = "Subscription"
+)
+
+// Schema is every operation of a graphql service, built from SDL, go code or introspection
+type Schema struct {
+	Operations []*Operation
+	Types      map[string]*Object // output object and interface type other than root type, keyed by name
+}
+
+// Object is an output object or interface type, eg. type Product { id: ID! }
+type Object struct {
+	Name   string
+	Fields []Field
+}
+
+// Field is a field of an object
+type Field struct {
+	Name       string
+	Type       string // in SDL notation, eg. [Product!]!
+	Deprecated bool
+}
+
+// Field return field of o called name
+func (o *Object) Field(name string) (Field, bool) {
+	for _, f := range o.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// NamedType return type without list and non null, eg. [Product!]! -> Product
+func NamedType(t string) string {
+	return strings.Trim(t, "[]!")
+}
+
+// Operation is a field of the Query, Mutation or Subscription root type, eg. getProduct
+type Operation struct {
+	Root              string // Query, Mutation or Subscription
+	Name              string
+	Args              []Argument
+	Type              string // return type in SDL notation, eg. [Product!]!
+	Deprecated        bool
+	DeprecationReason string
+	Pos               token.Position // where the operation is declared
+}
+
+// Argument is an argument of an operation
+type Argument struct {
+	Name    string
+	Type    string // eg. Int!
+	Default string // default value in SDL notation, empty if none
+}
+
+// Required will check if argument must be supplied, non null argument without default value
+func (a Argument) Required() bool {
+	return strings.HasSuffix(a.Type, "!") && a.Default == ""
+}
+
+// Signature return operation in SDL notation, eg. getProduct(id: Int!): Product
+func (o *Operation) Signature() string {
+	var args []string
+	for _, arg := range o.Args {
+		s := arg.Name + ": " + arg.Type
+		if arg.Default != "" {
+			s += " = " + arg.Default
+		}
+		args = append(args, s)
+	}
+	signature := o.Name
+	if len(args) > 0 {
+		signature += "(" + strings.Join(args, ", ") + ")"
+	}
+	if o.Type == "" { // type can not be resolved, eg. graphql-go field returned by a function
+		return signature
+	}
+	return signature + ": " + o.Type
+}
+
+// Note will describe operation for the sheet, eg. getProduct(id: Int!): Product at schema.graphqls:3
+func (o *Operation) Note() string {
+	note := o.Signature()
+	if o.Pos.Filename != "" {
+		note += fmt.Sprintf(" at %s:%d", filepath.Base(o.Pos.Filename), o.Pos.Line)
+	}
+	if o.Deprecated {
+		note = "Deprecated, " + note
+		if o.DeprecationReason != "" {
+			note += ": " + o.DeprecationReason
+		}
+	}
+	return note
+}
+
+// SheetType return value of the Type column, eg. Queries
+func (o *Operation) SheetType() string {
+	switch o.Root {
+	case Query:
+		return "Queries"
+	default:
+		return o.Root
+	}
+}
+
+// add will append operation, operation already declared in the same root is ignored
+func (s *Schema) add(op *Operation) {
+	for _, o := range s.Operations {
+		if o.Root == op.Root && o.Name == op.Name {
+			return
+		}
+	}
+	s.Operations = append(s.Operations, op)
+}
+
+// addField will append field to object type, field already declared is ignored
+// eg. field declared in both type Product and extend type Product
+func (s *Schema) addField(typeName string, field Field) {
+	if s.Types == nil {
+		s.Types = make(map[string]*Object)
+	}
+	obj, ok := s.Types[typeName]
+	if !ok {
+		obj = &Object{Name: typeName}
+		s.Types[typeName] = obj
+	}
+	if _, exist := obj.Field(field.Name); !exist {
+		obj.Fields = append(obj.Fields, field)
+	}
+}
+
+// Merge will add every operation and type of other into s
+func (s *Schema) Merge(other *Schema) {
+	for _, op := range other.Operations {
+		s.add(op)
+	}
+	for _, obj := range other.Types {
+		for _, field := range obj.Fields {
+			s.addField(obj.Name, field)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package gql
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	gqlast "github.com/vektah/gqlparser/v2/ast"
+	gqlparser "github.com/vektah/gqlparser/v2/parser"
+)
+
+// file extension of graphql schema file
+var sdlExtensions = map[string]bool{
+	".graphql":  true,
+	".graphqls": true,
+	".gql":      true,
+}
+
+// LoadSDL will parse every graphql schema file in path and return its operation
+// path can be a schema file, a directory (every .graphql, .graphqls and .gql file inside it)
+// or a go file with the schema in a string literal, eg. const schema = `type Query { ... }`
+func LoadSDL(path string) (*Schema, error) {
+	sources, err := sdlSources(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := gqlparser.ParseSchemas(sources...)
+	if err != nil {
+		return nil, err
+	}
+	return schemaFromDocument(doc), nil
+}
+
+// sdlSources return every schema source in path
+func sdlSources(path string) ([]*gqlast.Source, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	if info.IsDir() {
+		err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && sdlExtensions[filepath.Ext(p)] {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		files = append(files, path)
+	}
+
+	var sources []*gqlast.Source
+	for _, file := range files {
+		if filepath.Ext(file) == ".go" {
+			found, err := goSDLSources(file)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, found...)
+			continue
+		}
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, &gqlast.Source{Name: file, Input: string(content)})
+	}
+	return sources, nil
+}
+
+// goSDLSources return every string literal in go file that declare a root type, eg. type Query { ... }
+// literal that is not a valid schema (eg. a query or a description mentioning type Query) is skipped with a warning
+func goSDLSources(path string) ([]*gqlast.Source, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []*gqlast.Source
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		value, err := strconv.Unquote(lit.Value)
+		if err != nil || !declareRoot(value) {
+			return true
+		}
+		// pad with empty line so position of the schema is the same as in go file
+		pos := fset.Position(lit.Pos())
+		source := &gqlast.Source{Name: path, Input: strings.Repeat("\n", pos.Line-1) + value}
+		if _, err := gqlparser.ParseSchema(source); err != nil {
+			fmt.Fprintf(os.Stderr, "skip string literal, not a graphql schema: %v\n", err) // err has the file and line
+			return true
+		}
+		sources = append(sources, source)
+		return true
+	})
+	return sources, nil
+}
+
+// declareRoot will check if sdl declare or extend a root type
+func declareRoot(sdl string) bool {
+	for _, root := range []string{Query, Mutation, Subscription} {
+		if strings.Contains(sdl, "type "+root) {
+			return true
+		}
+	}
+	return strings.Contains(sdl, "schema {") || strings.Contains(sdl, "schema{")
+}
+
+// schemaFromDocument return operation of every root type and field of every other object in doc, including extend type
+// root type name is taken from schema { query: RootQuery } if declared, then only type named there is a root
+func schemaFromDocument(doc *gqlast.SchemaDocument) *Schema {
+	operations := map[gqlast.Operation]string{gqlast.Query: Query, gqlast.Mutation: Mutation, gqlast.Subscription: Subscription}
+	roots := map[string]string{Query: Query, Mutation: Mutation, Subscription: Subscription}
+	if len(doc.Schema) > 0 || len(doc.SchemaExtension) > 0 {
+		roots = make(map[string]string) // only type named in schema { ... } is a root
+	}
+	for _, list := range []gqlast.SchemaDefinitionList{doc.Schema, doc.SchemaExtension} {
+		for _, def := range list {
+			for _, op := range def.OperationTypes {
+				roots[op.Type] = operations[op.Operation]
+			}
+		}
+	}
+
+	schema := &Schema{}
+	for _, list := range []gqlast.DefinitionList{doc.Definitions, doc.Extensions} {
+		for _, def := range list {
+			if def.Kind != gqlast.Object && def.Kind != gqlast.Interface {
+				continue
+			}
+			root, ok := roots[def.Name]
+			for _, field := range def.Fields {
+				if ok {
+					schema.add(operationFromField(root, field))
+				} else {
+					schema.addField(def.Name, Field{
+						Name:       field.Name,
+						Type:       field.Type.String(),
+						Deprecated: field.Directives.ForName("deprecated") != nil,
+					})
+				}
+			}
+		}
+	}
+	return schema
+}
+
+// operationFromField return operation of a root type field
+func operationFromField(root string, field *gqlast.FieldDefinition) *Operation {
+	op := &Operation{
+		Root: root,
+		Name: field.Name,
+		Type: field.Type.String(),
+	}
+	for _, arg := range field.Arguments {
+		a := Argument{Name: arg.Name, Type: arg.Type.String()}
+		if arg.DefaultValue != nil {
+			a.Default = arg.DefaultValue.String()
+		}
+		op.Args = append(op.Args, a)
+	}
+	if deprecated := field.Directives.ForName("deprecated"); deprecated != nil {
+		op.Deprecated = true
+		if reason := deprecated.Arguments.ForName("reason"); reason != nil && reason.Value != nil {
+			op.DeprecationReason = reason.Value.Raw
+		}
+	}
+	if field.Position != nil && field.Position.Src != nil {
+		op.Pos = token.Position{Filename: field.Position.Src.Name, Line: field.Position.Line, Column: field.Position.Column}
+	}
+	return op
+}
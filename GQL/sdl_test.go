@@ -0,0 +1,199 @@
+package gql
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// writeFiles will write every file in a temp directory and return the directory
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// operations return every operation of schema as "Root signature", sorted
+func operations(schema *Schema) []string {
+	var got []string
+	for _, op := range schema.Operations {
+		got = append(got, op.Root+" "+op.Signature())
+	}
+	sort.Strings(got)
+	return got
+}
+
+func TestLoadSDL(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		want      []string
+		wantTypes []string // object type other than root type
+	}{
+		{
+			name: "default root name",
+			files: map[string]string{
+				"schema.graphqls": `type Query {
+  getProduct(id: Int!, lang: String = "en"): Product
+}
+
+type Mutation {
+  addCart(productId: Int!): Boolean!
+}
+
+type Subscription {
+  cartUpdated: Int
+}
+
+type Product {
+  id: ID!
+}
+`,
+			},
+			want: []string{
+				"Mutation addCart(productId: Int!): Boolean!",
+				`Query getProduct(id: Int!, lang: String = "en"): Product`,
+				"Subscription cartUpdated: Int",
+			},
+			wantTypes: []string{"Product"},
+		},
+		{
+			name: "explicit schema block",
+			files: map[string]string{
+				"schema.graphqls": `schema {
+  query: RootQuery
+  mutation: RootMutation
+}
+
+type RootQuery {
+  getProduct(id: Int!): Product
+}
+
+type RootMutation {
+  addCart(productId: Int!): Boolean!
+}
+
+type Query {
+  notRoot: Int
+}
+
+type Subscription {
+  notRootEither: Int
+}
+
+type Product {
+  id: ID!
+}
+`,
+			},
+			want: []string{
+				"Mutation addCart(productId: Int!): Boolean!",
+				"Query getProduct(id: Int!): Product",
+			},
+			wantTypes: []string{"Product", "Query", "Subscription"},
+		},
+		{
+			name: "extend type in other file",
+			files: map[string]string{
+				"a.graphqls": `type Query {
+  getProduct(id: Int!): Product
+}
+
+type Product {
+  id: ID!
+}
+`,
+				"b.graphql": `extend type Query {
+  getCart: [Product!]!
+}
+
+extend type Product {
+  name: String @deprecated
+}
+`,
+				"readme.md": "type Query { ignored: Int }",
+			},
+			want: []string{
+				"Query getCart: [Product!]!",
+				"Query getProduct(id: Int!): Product",
+			},
+			wantTypes: []string{"Product"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := LoadSDL(writeFiles(t, tt.files))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := operations(schema); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("operations\ngot  %q\nwant %q", got, tt.want)
+			}
+			var types []string
+			for name := range schema.Types {
+				types = append(types, name)
+			}
+			sort.Strings(types)
+			if !reflect.DeepEqual(types, tt.wantTypes) {
+				t.Errorf("types = %v, want %v", types, tt.wantTypes)
+			}
+		})
+	}
+}
+
+func TestLoadSDLExtendType(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"schema.graphqls": `type Query {
+  getProduct(id: Int!): Product @deprecated(reason: "use product")
+}
+
+type Product {
+  id: ID!
+}
+
+extend type Product {
+  name: String @deprecated
+}
+`,
+	})
+	schema, err := LoadSDL(filepath.Join(dir, "schema.graphqls"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := schema.Operations[0]
+	if op.Pos.Line != 2 || op.Pos.Column != 3 || !op.Deprecated || op.DeprecationReason != "use product" {
+		t.Errorf("getProduct = %+v", op)
+	}
+	want := []Field{{Name: "id", Type: "ID!"}, {Name: "name", Type: "String", Deprecated: true}}
+	if got := schema.Types["Product"].Fields; !reflect.DeepEqual(got, want) {
+		t.Errorf("Product fields = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadSDLGoSource(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"schema.go": "package graph\n\n" +
+			"const schema = `\ntype Query {\n  getProduct(id: Int!): Int\n}\n`\n\n" +
+			"// not a schema, only mention type Query\n" +
+			"const doc = \"type Query is the root\"\n",
+	})
+	schema, err := LoadSDL(filepath.Join(dir, "schema.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := operations(schema), []string{"Query getProduct(id: Int!): Int"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("operations = %q, want %q", got, want)
+	}
+	// literal is padded, so position is the line in go file
+	if line := schema.Operations[0].Pos.Line; line != 5 {
+		t.Errorf("getProduct line = %d, want 5", line)
+	}
+}
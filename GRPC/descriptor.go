@@ -0,0 +1,140 @@
+package grpc
+
+import (
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// field number used in SourceCodeInfo location path, see descriptor.proto
+const (
+	fileServiceField   = 6 // FileDescriptorProto.service
+	serviceMethodField = 2 // ServiceDescriptorProto.method
+)
+
+// LoadDescriptorSet will read every rpc in a binary FileDescriptorSet in path,
+// eg. protoc --descriptor_set_out=app.pb or buf build -o app.bin
+// position is only known when the set is built with --include_source_info
+func LoadDescriptorSet(path string) (*Protos, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(content, &set); err != nil {
+		return nil, &os.PathError{Op: "parse", Path: path, Err: err}
+	}
+	if len(set.File) == 0 {
+		return nil, &os.PathError{Op: "parse", Path: path, Err: fmt.Errorf("no file descriptor, not a FileDescriptorSet")}
+	}
+
+	protos := &Protos{}
+	for _, file := range set.File {
+		for _, message := range file.MessageType {
+			addDescriptorMessage(protos, file.GetPackage(), message)
+		}
+		for _, enum := range file.EnumType {
+			addDescriptorEnum(protos, file.GetPackage(), enum)
+		}
+
+		// line of every declaration keyed by its location path, eg. [6 0 2 1] is the second method of the first service
+		lines := make(map[string]int)
+		for _, location := range file.GetSourceCodeInfo().GetLocation() {
+			if len(location.Span) > 0 {
+				lines[fmt.Sprint(location.Path)] = int(location.Span[0]) + 1
+			}
+		}
+
+		for si, service := range file.Service {
+			for mi, method := range service.Method {
+				rpc := &RPC{
+					Package:         file.GetPackage(),
+					Service:         service.GetName(),
+					Name:            method.GetName(),
+					Input:           strings.TrimPrefix(method.GetInputType(), "."),
+					Output:          strings.TrimPrefix(method.GetOutputType(), "."),
+					ClientStreaming: method.GetClientStreaming(),
+					ServerStreaming: method.GetServerStreaming(),
+					Deprecated:      method.GetOptions().GetDeprecated() || service.GetOptions().GetDeprecated(),
+					HTTP:            descriptorHTTPRules(method.GetOptions()),
+				}
+				path := []int32{fileServiceField, int32(si), serviceMethodField, int32(mi)}
+				if line, ok := lines[fmt.Sprint(path)]; ok {
+					rpc.Pos = token.Position{Filename: file.GetName(), Line: line}
+				}
+				protos.add(rpc)
+			}
+		}
+	}
+	return protos, nil
+}
+
+// addDescriptorMessage will register message declared in scope (package or parent message) and its nested message and enum
+// map field is stored as a synthetic XxxEntry nested message, it is read as the map key and value type instead
+func addDescriptorMessage(protos *Protos, scope string, m *descriptorpb.DescriptorProto) {
+	name := m.GetName()
+	if scope != "" {
+		name = scope + "." + name
+	}
+
+	entries := make(map[string]*descriptorpb.DescriptorProto) // map entry keyed by fully qualified name
+	for _, nested := range m.NestedType {
+		if nested.GetOptions().GetMapEntry() {
+			entries[name+"."+nested.GetName()] = nested
+			continue
+		}
+		addDescriptorMessage(protos, name, nested)
+	}
+	for _, enum := range m.EnumType {
+		addDescriptorEnum(protos, name, enum)
+	}
+
+	message := &Message{Name: name}
+	for _, f := range m.Field {
+		field := &MessageField{
+			Name:     f.GetName(),
+			JSONName: f.GetJsonName(),
+			Type:     descriptorType(f),
+			Repeated: f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED,
+		}
+		if field.JSONName == "" {
+			field.JSONName = jsonName(field.Name)
+		}
+		if entry, ok := entries[field.Type]; ok && len(entry.Field) == 2 {
+			field.Key = descriptorType(entry.Field[0])
+			field.Type = descriptorType(entry.Field[1])
+			field.Repeated = false
+		}
+		// proto3 optional is a synthetic oneof with a single field, not a real choice
+		if f.OneofIndex != nil && !f.GetProto3Optional() && int(f.GetOneofIndex()) < len(m.OneofDecl) {
+			field.Oneof = m.OneofDecl[f.GetOneofIndex()].GetName()
+		}
+		message.Fields = append(message.Fields, field)
+	}
+	protos.addMessage(message)
+}
+
+// addDescriptorEnum will register enum declared in scope (package or parent message)
+func addDescriptorEnum(protos *Protos, scope string, e *descriptorpb.EnumDescriptorProto) {
+	enum := &Enum{Name: e.GetName()}
+	if scope != "" {
+		enum.Name = scope + "." + e.GetName()
+	}
+	for _, value := range e.Value {
+		enum.Values = append(enum.Values, EnumValue{Name: value.GetName(), Number: int(value.GetNumber())})
+	}
+	protos.addEnum(enum)
+}
+
+// descriptorType return field type in proto notation, eg. TYPE_INT64 -> int64, TYPE_MESSAGE .sampleapp.Filter -> sampleapp.Filter
+func descriptorType(f *descriptorpb.FieldDescriptorProto) string {
+	if f.GetTypeName() != "" {
+		return strings.TrimPrefix(f.GetTypeName(), ".")
+	}
+	return strings.ToLower(strings.TrimPrefix(f.GetType().String(), "TYPE_"))
+}
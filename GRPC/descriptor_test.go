@@ -0,0 +1,226 @@
+package grpc
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// writeDescriptorSet will save set as a binary FileDescriptorSet in a temp file and return its path
+func writeDescriptorSet(t *testing.T, set *descriptorpb.FileDescriptorSet) string {
+	t.Helper()
+	content, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "app.pb")
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// httpRuleBytes return a serialized google.api.HttpRule with a get pattern and additional_bindings
+func httpRuleBytes(get string, bindings ...[]byte) []byte {
+	var b []byte
+	b = protowire.AppendTag(b, httpRuleGet, protowire.BytesType)
+	b = protowire.AppendString(b, get)
+	for _, binding := range bindings {
+		b = protowire.AppendTag(b, httpRuleAdditionalBindings, protowire.BytesType)
+		b = protowire.AppendBytes(b, binding)
+	}
+	return b
+}
+
+// customRuleBytes return a serialized google.api.HttpRule with a custom pattern, empty kind or path is not set
+func customRuleBytes(kind, path string) []byte {
+	var pattern []byte
+	if kind != "" {
+		pattern = protowire.AppendTag(pattern, customPatternKind, protowire.BytesType)
+		pattern = protowire.AppendString(pattern, kind)
+	}
+	if path != "" {
+		pattern = protowire.AppendTag(pattern, customPatternPath, protowire.BytesType)
+		pattern = protowire.AppendString(pattern, path)
+	}
+	var b []byte
+	b = protowire.AppendTag(b, httpRuleCustom, protowire.BytesType)
+	return protowire.AppendBytes(b, pattern)
+}
+
+// methodOptions return MethodOptions with rule as the unknown google.api.http extension
+func methodOptions(deprecated bool, rule []byte) *descriptorpb.MethodOptions {
+	options := &descriptorpb.MethodOptions{Deprecated: proto.Bool(deprecated)}
+	if rule != nil {
+		var b []byte
+		b = protowire.AppendTag(b, httpRuleField, protowire.BytesType)
+		b = protowire.AppendBytes(b, rule)
+		options.ProtoReflect().SetUnknown(b)
+	}
+	return options
+}
+
+func sampleDescriptorSet() *descriptorpb.FileDescriptorSet {
+	s, i := proto.String, proto.Int32
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	int64Type := descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+	message := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	enum := descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum()
+
+	return &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:    s("sampleapp/v1/product.proto"),
+		Package: s("sampleapp"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name:  s("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{{Name: s("STATUS_UNSPECIFIED"), Number: i(0)}, {Name: s("ACTIVE"), Number: i(1)}},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: s("ListProductRequest"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: s("labels"), JsonName: s("labels"), Label: repeated, Type: message, TypeName: s(".sampleapp.ListProductRequest.LabelsEntry")},
+				{Name: s("filters"), JsonName: s("filters"), Label: repeated, Type: message, TypeName: s(".sampleapp.ListProductRequest.Filter")},
+				{Name: s("by_name"), JsonName: s("byName"), Label: optional, Type: str, OneofIndex: i(0)},
+				{Name: s("by_id"), JsonName: s("byId"), Label: optional, Type: int64Type, OneofIndex: i(0)},
+				{Name: s("page_token"), Label: optional, Type: str, OneofIndex: i(1), Proto3Optional: proto.Bool(true)},
+			},
+			NestedType: []*descriptorpb.DescriptorProto{
+				{
+					Name:    s("LabelsEntry"),
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+					Field: []*descriptorpb.FieldDescriptorProto{
+						{Name: s("key"), Number: i(1), Type: str},
+						{Name: s("value"), Number: i(2), Type: enum, TypeName: s(".sampleapp.Status")},
+					},
+				},
+				{
+					Name:  s("Filter"),
+					Field: []*descriptorpb.FieldDescriptorProto{{Name: s("status"), JsonName: s("status"), Type: enum, TypeName: s(".sampleapp.Status")}},
+				},
+			},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: s("lookup")}, {Name: s("_page_token")}},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: s("ProductService"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{
+						Name: s("GetProduct"), InputType: s(".sampleapp.ListProductRequest"), OutputType: s(".sampleapp.ListProductRequest"),
+						Options: methodOptions(false, httpRuleBytes("/v1/products/{id}", customRuleBytes("HEAD", "/v1/products/{id}"))),
+					},
+					{
+						Name: s("ListProduct"), InputType: s(".sampleapp.ListProductRequest"), OutputType: s(".sampleapp.ListProductRequest"),
+						ServerStreaming: proto.Bool(true), Options: methodOptions(true, nil),
+					},
+				},
+			},
+			{
+				Name:    s("Legacy"),
+				Options: &descriptorpb.ServiceOptions{Deprecated: proto.Bool(true)},
+				Method:  []*descriptorpb.MethodDescriptorProto{{Name: s("Ping"), InputType: s(".google.protobuf.Empty"), OutputType: s(".google.protobuf.Empty")}},
+			},
+		},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{
+			{Path: []int32{fileServiceField, 0}, Span: []int32{9, 0, 20, 1}},
+			{Path: []int32{fileServiceField, 0, serviceMethodField, 0}, Span: []int32{10, 2, 60}},
+			{Path: []int32{fileServiceField, 0, serviceMethodField, 1}, Span: []int32{13, 2, 70}},
+		}},
+	}}}
+}
+
+func TestLoadDescriptorSet(t *testing.T) {
+	protos, err := LoadDescriptorSet(writeDescriptorSet(t, sampleDescriptorSet()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, rpc := range protos.RPCs {
+		got = append(got, rpc.Note())
+	}
+	want := []string{
+		"rpc GetProduct(sampleapp.ListProductRequest) returns (sampleapp.ListProductRequest) at product.proto:11, REST GET /v1/products/{id}, REST HEAD /v1/products/{id}",
+		"Deprecated, rpc ListProduct(sampleapp.ListProductRequest) returns (stream sampleapp.ListProductRequest) at product.proto:14",
+		"Deprecated, rpc Ping(google.protobuf.Empty) returns (google.protobuf.Empty)", // no source info
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rpcs\ngot  %q\nwant %q", got, want)
+	}
+
+	m := protos.Messages["sampleapp.ListProductRequest"]
+	if m == nil {
+		t.Fatal("ListProductRequest not found")
+	}
+	if _, ok := protos.Messages["sampleapp.ListProductRequest.LabelsEntry"]; ok {
+		t.Error("map entry should not be registered as a message")
+	}
+	if _, ok := protos.Messages["sampleapp.ListProductRequest.Filter"]; !ok {
+		t.Error("nested message Filter not found")
+	}
+
+	tests := []struct {
+		field string
+		want  MessageField
+	}{
+		{"labels", MessageField{Name: "labels", JSONName: "labels", Type: "sampleapp.Status", Key: "string"}},
+		{"filters", MessageField{Name: "filters", JSONName: "filters", Type: "sampleapp.ListProductRequest.Filter", Repeated: true}},
+		{"byName", MessageField{Name: "by_name", JSONName: "byName", Type: "string", Oneof: "lookup"}},
+		{"by_id", MessageField{Name: "by_id", JSONName: "byId", Type: "int64", Oneof: "lookup"}},
+		{"pageToken", MessageField{Name: "page_token", JSONName: "pageToken", Type: "string"}}, // proto3 optional is not a oneof
+	}
+	for _, tt := range tests {
+		field, ok := m.Field(tt.field)
+		if !ok {
+			t.Errorf("field %s not found", tt.field)
+			continue
+		}
+		if !reflect.DeepEqual(*field, tt.want) {
+			t.Errorf("field %s = %+v, want %+v", tt.field, *field, tt.want)
+		}
+	}
+}
+
+func TestLoadDescriptorSetInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.proto")
+	if err := ioutil.WriteFile(path, []byte("syntax = \"proto3\";"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDescriptorSet(path); err == nil {
+		t.Error("LoadDescriptorSet(text proto) should fail")
+	}
+	if _, err := LoadDescriptorSet(writeDescriptorSet(t, &descriptorpb.FileDescriptorSet{})); err == nil {
+		t.Error("LoadDescriptorSet(empty set) should fail")
+	}
+}
+
+func TestDecodeHTTPRule(t *testing.T) {
+	rule := httpRuleBytes("/v1/{name=shops/*}/products",
+		customRuleBytes("head", "/v1/products"),
+		customRuleBytes("OPTIONS", ""), // incomplete custom pattern is skipped
+		customRuleBytes("", "/v1/broken"),
+		httpRuleBytes("/v2/products", customRuleBytes("HEAD", "/v2/products")), // nested binding
+	)
+	got := decodeHTTPRule(rule)
+	want := []HTTPRule{
+		{Method: "GET", Path: "/v1/{name=shops/*}/products"},
+		{Method: "HEAD", Path: "/v1/products"},
+		{Method: "GET", Path: "/v2/products"},
+		{Method: "HEAD", Path: "/v2/products"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decodeHTTPRule() = %v, want %v", got, want)
+	}
+
+	if got := descriptorHTTPRules(nil); got != nil {
+		t.Errorf("descriptorHTTPRules(nil) = %v", got)
+	}
+	if got := decodeHTTPRule([]byte{0xff}); got != nil {
+		t.Errorf("decodeHTTPRule(malformed) = %v", got)
+	}
+}
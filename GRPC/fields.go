@@ -0,0 +1,186 @@
+package grpc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+)
+
+// fieldSheetName is the sheet listing every field of the request message of every rpc
+const fieldSheetName = "Request Fields"
+
+// requestCoverage hold which request field and enum value is populated by test of each rpc
+// field is keyed by its path from the request message, eg. filter.category
+type requestCoverage struct {
+	fields map[*RPC]map[string][]string        // test case name that populate each field
+	enums  map[*RPC]map[string]map[string]bool // enum value name used in each enum field
+}
+
+func newRequestCoverage() *requestCoverage {
+	return &requestCoverage{
+		fields: make(map[*RPC]map[string][]string),
+		enums:  make(map[*RPC]map[string]map[string]bool),
+	}
+}
+
+// add will record every field of the request message of rpc populated by variables of test
+func (c *requestCoverage) add(protos *Protos, rpc *RPC, variables map[string]interface{}, test string) {
+	if c.fields[rpc] == nil {
+		c.fields[rpc] = make(map[string][]string)
+		c.enums[rpc] = make(map[string]map[string]bool)
+	}
+	c.walk(protos, rpc, rpc.Input, variables, "", test)
+}
+
+// walk will record every field of message set in value, nested message is walked with prefix, eg. filter.
+// null value is not populated, repeated field is populated by every element of its json array
+func (c *requestCoverage) walk(protos *Protos, rpc *RPC, message string, value map[string]interface{}, prefix, test string) {
+	m, ok := protos.Messages[message]
+	if !ok {
+		return
+	}
+	for key, v := range value {
+		field, ok := m.Field(key)
+		if !ok || v == nil {
+			continue
+		}
+		path := prefix + field.Name
+		c.fields[rpc][path] = appendUnique(c.fields[rpc][path], test)
+		if field.Key != "" { // map value is not walked
+			continue
+		}
+
+		items := []interface{}{v}
+		if list, ok := v.([]interface{}); ok && field.Repeated {
+			items = list
+		}
+		for _, item := range items {
+			if enum, ok := protos.Enums[field.Type]; ok {
+				if name, ok := enum.ValueName(item); ok {
+					if c.enums[rpc][path] == nil {
+						c.enums[rpc][path] = make(map[string]bool)
+					}
+					c.enums[rpc][path][name] = true
+				}
+			}
+			if nested, ok := item.(map[string]interface{}); ok {
+				c.walk(protos, rpc, field.Type, nested, path+".", test)
+			}
+		}
+	}
+}
+
+// fieldPath is a field of a request message and its path, eg. filter.category
+type fieldPath struct {
+	Path  string
+	Field *MessageField
+}
+
+// fieldPaths return every field of message, including field of nested message
+// visited is message already expanded in the current path, used to stop recursive message
+func (p *Protos) fieldPaths(message, prefix string, visited map[string]bool) []fieldPath {
+	m, ok := p.Messages[message]
+	if !ok || visited[message] {
+		return nil
+	}
+	visited[message] = true
+	defer delete(visited, message)
+
+	var paths []fieldPath
+	for _, field := range m.Fields {
+		path := prefix + field.Name
+		paths = append(paths, fieldPath{path, field})
+		if field.Key == "" {
+			paths = append(paths, p.fieldPaths(field.Type, path+".", visited)...)
+		}
+	}
+	return paths
+}
+
+// writeFieldSheet will list every field of the request message of rpcs and the test case that populate it
+// field never populated is marked No TestCase, and enum value never sent is noted
+func writeFieldSheet(xlsx *excelize.File, protos *Protos, rpcs []*RPC, coverage *requestCoverage, status []string) {
+	xlsx.NewSheet(fieldSheetName)
+	sheet := fieldSheetName
+
+	// create column name
+	xlsx.SetCellValue(sheet, "A1", "Endpoint")
+	xlsx.SetCellValue(sheet, "B1", "Service")
+	xlsx.SetCellValue(sheet, "C1", "Field")
+	xlsx.SetCellValue(sheet, "D1", "Field Type")
+	xlsx.SetCellValue(sheet, "E1", "Tested")
+	xlsx.SetCellValue(sheet, "F1", "Test Case Name")
+	xlsx.SetCellValue(sheet, "G1", "Status")
+	xlsx.SetCellValue(sheet, "H1", "Notes")
+	xlsx.AutoFilter(sheet, "A1", "H1", "")
+
+	// add data validation for status column
+	dvRange := excelize.NewDataValidation(true)
+	dvRange.Sqref = "G:G"
+	dvRange.SetDropList(status)
+	xlsx.AddDataValidation(sheet, dvRange)
+
+	row := 1
+	for _, rpc := range rpcs {
+		paths := protos.fieldPaths(rpc.Input, "", make(map[string]bool))
+		if len(paths) == 0 {
+			continue
+		}
+		start := row + 1
+		for _, p := range paths {
+			row++
+			tests := coverage.fields[rpc][p.Path]
+			xlsx.SetCellValue(sheet, fmt.Sprintf("A%d", row), rpc.Name)
+			xlsx.SetCellValue(sheet, fmt.Sprintf("B%d", row), rpc.ServiceName())
+			xlsx.SetCellValue(sheet, fmt.Sprintf("C%d", row), p.Path)
+			xlsx.SetCellValue(sheet, fmt.Sprintf("D%d", row), p.Field.TypeString())
+			xlsx.SetCellValue(sheet, fmt.Sprintf("E%d", row), yesNo(len(tests) > 0))
+			xlsx.SetCellValue(sheet, fmt.Sprintf("F%d", row), strings.Join(tests, ", "))
+			if len(tests) > 0 {
+				xlsx.SetCellValue(sheet, fmt.Sprintf("G%d", row), "Live")
+			} else {
+				xlsx.SetCellValue(sheet, fmt.Sprintf("G%d", row), "No TestCase")
+			}
+
+			var notes []string
+			if p.Field.Oneof != "" {
+				notes = append(notes, "oneof "+p.Field.Oneof)
+			}
+			if enum, ok := protos.Enums[p.Field.Type]; ok {
+				var unused []string
+				for _, value := range enum.Values {
+					if !coverage.enums[rpc][p.Path][value.Name] {
+						unused = append(unused, value.Name)
+					}
+				}
+				if len(unused) > 0 {
+					notes = append(notes, "unused enum value: "+strings.Join(unused, ", "))
+				}
+			}
+			xlsx.SetCellValue(sheet, fmt.Sprintf("H%d", row), strings.Join(notes, ", "))
+		}
+
+		// merge cell of the same endpoint
+		xlsx.MergeCell(sheet, "A"+strconv.Itoa(start), "A"+strconv.Itoa(row))
+		xlsx.MergeCell(sheet, "B"+strconv.Itoa(start), "B"+strconv.Itoa(row))
+	}
+}
+
+// appendUnique will append value to list if it is not in list yet
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
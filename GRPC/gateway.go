@@ -0,0 +1,249 @@
+package grpc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	protoparser "github.com/emicklei/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	api "github.com/IndraWirananta/IntegrationTestRelatedScript/API"
+	"github.com/IndraWirananta/IntegrationTestRelatedScript/testcase"
+)
+
+// httpRuleField is the field number of the google.api.http extension of MethodOptions, see google/api/annotations.proto
+const httpRuleField = 72295728
+
+// field number of google.api.HttpRule, see google/api/http.proto
+const (
+	httpRuleGet                = 2
+	httpRulePut                = 3
+	httpRulePost               = 4
+	httpRuleDelete             = 5
+	httpRulePatch              = 6
+	httpRuleCustom             = 8
+	httpRuleAdditionalBindings = 11
+	customPatternKind          = 1
+	customPatternPath          = 2
+)
+
+// httpVariable is a path variable of google.api.http, eg. {id} or {name=products/*}
+var httpVariable = regexp.MustCompile(`\{([^}=]+)(?:=([^}]*))?\}`)
+
+// HTTPRule is a REST route of an rpc exposed by grpc-gateway, from option (google.api.http)
+type HTTPRule struct {
+	Method string // eg. GET
+	Path   string // as annotated, eg. /v1/{name=products/*}
+}
+
+// String return rule as method and path, eg. GET /v1/products/{id}
+func (h HTTPRule) String() string {
+	return h.Method + " " + h.Path
+}
+
+// RoutePath return Path in api sweep notation, variable with a pattern is expanded into its segment
+// eg. /v1/{name=products/*} -> /v1/products/{name} and /v1/{path=files/**} -> /v1/files/{path...}
+// verb of the last segment is dropped, eg. /v1/{name=ops/*}:cancel -> /v1/ops/{name}
+func (h HTTPRule) RoutePath() string {
+	path := h.Path
+	if i := strings.LastIndex(path, ":"); i > strings.LastIndex(path, "/") && i > strings.LastIndex(path, "}") {
+		path = path[:i]
+	}
+	return httpVariable.ReplaceAllStringFunc(path, func(variable string) string {
+		match := httpVariable.FindStringSubmatch(variable)
+		if match[2] == "" {
+			return "{" + match[1] + "}"
+		}
+		segments := strings.Split(match[2], "/")
+		for i, segment := range segments {
+			switch segment {
+			case "*":
+				segments[i] = "{" + match[1] + "}"
+			case "**":
+				segments[i] = "{" + match[1] + "...}"
+			}
+		}
+		return strings.Join(segments, "/")
+	})
+}
+
+// httpRules return every rule in option (google.api.http) of rpc elements, including additional_bindings
+func httpRules(elements []protoparser.Visitee) []HTTPRule {
+	var rules []HTTPRule
+	for _, element := range elements {
+		if option, ok := element.(*protoparser.Option); ok && option.Name == "(google.api.http)" {
+			rules = append(rules, httpRulesFromLiteral(option.Constant.OrderedMap)...)
+		}
+	}
+	return rules
+}
+
+// httpRulesFromLiteral return rule of HttpRule aggregate, eg. { get: "/v1/products/{id}" additional_bindings { ... } }
+func httpRulesFromLiteral(m protoparser.LiteralMap) []HTTPRule {
+	var rules []HTTPRule
+	for _, entry := range m {
+		switch entry.Name {
+		case "get", "put", "post", "delete", "patch":
+			rules = append(rules, HTTPRule{Method: strings.ToUpper(entry.Name), Path: entry.Source})
+		case "custom":
+			kind, _ := entry.OrderedMap.Get("kind")
+			path, _ := entry.OrderedMap.Get("path")
+			if kind.Source == "" || path.Source == "" {
+				continue // incomplete custom pattern
+			}
+			rules = append(rules, HTTPRule{Method: strings.ToUpper(kind.Source), Path: path.Source})
+		case "additional_bindings":
+			rules = append(rules, httpRulesFromLiteral(entry.OrderedMap)...)
+			for _, binding := range entry.Array {
+				rules = append(rules, httpRulesFromLiteral(binding.OrderedMap)...)
+			}
+		}
+	}
+	return rules
+}
+
+// descriptorHTTPRules return every rule in the google.api.http extension of options
+// the extension is not registered, so it is decoded from the unknown field of options
+func descriptorHTTPRules(options *descriptorpb.MethodOptions) []HTTPRule {
+	if options == nil {
+		return nil
+	}
+	var rules []HTTPRule
+	eachField(options.ProtoReflect().GetUnknown(), func(num protowire.Number, value []byte) {
+		if num == httpRuleField {
+			rules = append(rules, decodeHTTPRule(value)...)
+		}
+	})
+	return rules
+}
+
+// decodeHTTPRule return rule of a serialized google.api.HttpRule, including additional_bindings
+func decodeHTTPRule(b []byte) []HTTPRule {
+	methods := map[protowire.Number]string{httpRuleGet: "GET", httpRulePut: "PUT", httpRulePost: "POST", httpRuleDelete: "DELETE", httpRulePatch: "PATCH"}
+	var rules []HTTPRule
+	eachField(b, func(num protowire.Number, value []byte) {
+		switch num {
+		case httpRuleGet, httpRulePut, httpRulePost, httpRuleDelete, httpRulePatch:
+			rules = append(rules, HTTPRule{Method: methods[num], Path: string(value)})
+		case httpRuleCustom:
+			var rule HTTPRule
+			eachField(value, func(num protowire.Number, value []byte) {
+				switch num {
+				case customPatternKind:
+					rule.Method = strings.ToUpper(string(value))
+				case customPatternPath:
+					rule.Path = string(value)
+				}
+			})
+			if rule.Method != "" && rule.Path != "" { // skip incomplete custom pattern
+				rules = append(rules, rule)
+			}
+		case httpRuleAdditionalBindings:
+			rules = append(rules, decodeHTTPRule(value)...)
+		}
+	})
+	return rules
+}
+
+// eachField will call fn for every length delimited field in serialized message b
+// other wire type is skipped, decoding stop at the first malformed field
+func eachField(b []byte, fn func(num protowire.Number, value []byte)) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return
+		}
+		b = b[n:]
+		if typ == protowire.BytesType {
+			value, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return
+			}
+			fn(num, value)
+			b = b[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return
+		}
+		b = b[n:]
+	}
+}
+
+// GatewayRoutes return REST route of every rpc annotated with google.api.http in cfg.ProtoPath,
+// along with grpc integration test of the rpc in cfg.IntegrationPath, used by api sweep to cross link both sweep
+// invoke template is only used to match grpc test, it is not checked when cfg.IntegrationPath is empty
+func GatewayRoutes(cfg Config) ([]api.Route, error) {
+	var parsed *Protos
+	var byInvoke map[string]*RPC
+	var err error
+	if cfg.IntegrationPath == "" {
+		parsed, err = loadProtos(cfg.ProtoPath)
+	} else {
+		parsed, byInvoke, err = loadEndpoints(cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tested := make(map[*RPC][]string) // grpc test case name of each rpc
+	if cfg.IntegrationPath != "" {
+		err := testcase.Walk(cfg.IntegrationPath, func(path string, result *testcase.IntegrationTest, err error) error {
+			if err != nil {
+				return nil // already reported by grpc sweep
+			}
+			if _, ok := result.First(cfg.Envs); !ok {
+				return nil
+			}
+			if rpc, ok := byInvoke[invokeKey(result.ApiName)]; ok {
+				tested[rpc] = append(tested[rpc], result.QueryName)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	routes := gatewayRoutes(parsed.RPCs)
+	byName := make(map[string]*RPC)
+	for _, rpc := range parsed.RPCs {
+		byName[rpc.FullName()] = rpc
+	}
+	for i, route := range routes {
+		routes[i].RPCTests = tested[byName[route.RPC]]
+	}
+	return routes, nil
+}
+
+// gatewayRoutes return REST route of every google.api.http rule of rpcs, handler is the rpc full name
+// two rpc annotated with the same route is ambiguous, only the first is linked and the other is reported
+func gatewayRoutes(rpcs []*RPC) []api.Route {
+	var routes []api.Route
+	seen := make(map[string]string) // rpc full name keyed by route key
+	for _, rpc := range rpcs {
+		for _, rule := range rpc.HTTP {
+			route := api.Route{
+				Method:     rule.Method,
+				Path:       rule.RoutePath(),
+				Handler:    rpc.FullName(),
+				Pos:        rpc.Pos,
+				Tags:       []string{"grpc-gateway"},
+				Deprecated: rpc.Deprecated,
+				RPC:        rpc.FullName(),
+			}
+			if first, ok := seen[route.Key()]; ok {
+				if first != rpc.FullName() {
+					fmt.Printf("REST %s of %s is also annotated on %s, only %s is linked\n", rule, rpc.FullName(), first, first)
+				}
+				continue
+			}
+			seen[route.Key()] = rpc.FullName()
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
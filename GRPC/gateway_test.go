@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGatewayRoutes(t *testing.T) {
+	get := &RPC{Package: "sampleapp", Service: "ProductService", Name: "GetProduct", HTTP: []HTTPRule{{"GET", "/v1/products/{id}"}, {"GET", "/v1/products/{product_id}"}}}
+	legacy := &RPC{Package: "sampleapp", Service: "LegacyService", Name: "GetProduct", Deprecated: true, HTTP: []HTTPRule{{"GET", "/v1/products/{id}"}, {"DELETE", "/v1/products/{id}"}}}
+
+	var got []string
+	for _, route := range gatewayRoutes([]*RPC{get, legacy}) {
+		got = append(got, route.Method+" "+route.Path+" "+route.RPC)
+	}
+	// same route of the same rpc is listed once, route of another rpc is only linked to the first rpc
+	want := []string{
+		"GET /v1/products/{id} sampleapp.ProductService/GetProduct",
+		"DELETE /v1/products/{id} sampleapp.LegacyService/GetProduct",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("gatewayRoutes()\ngot  %q\nwant %q", got, want)
+	}
+}
+
+func TestRoutePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/v1/products/{id}", "/v1/products/{id}"},
+		{"/v1/{name=products/*}", "/v1/products/{name}"},
+		{"/v1/{name=shops/*/products/*}", "/v1/shops/{name}/products/{name}"},
+		{"/v1/{path=files/**}", "/v1/files/{path...}"},
+		{"/v1/{name=ops/*}:cancel", "/v1/ops/{name}"}, // verb is dropped
+		{"/v1/products:batchGet", "/v1/products"},
+		{"/v1/products/{id}:archive", "/v1/products/{id}"},
+	}
+	for _, tt := range tests {
+		if got := (HTTPRule{Method: "GET", Path: tt.path}).RoutePath(); got != tt.want {
+			t.Errorf("RoutePath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestHTTPRules(t *testing.T) {
+	src := `syntax = "proto3";
+
+package sampleapp;
+
+service ProductService {
+  rpc GetProduct(GetProductRequest) returns (Product) {
+    option (google.api.http) = {
+      get: "/v1/products/{id}"
+      additional_bindings { custom: { kind: "HEAD" path: "/v1/products/{id}" } }
+      additional_bindings { custom: { kind: "OPTIONS" } }
+      additional_bindings { custom: { path: "/v1/broken" } }
+      additional_bindings { post: "/v1/{name=ops/*}:cancel" }
+    };
+  }
+}
+`
+	path := filepath.Join(t.TempDir(), "product.proto")
+	if err := ioutil.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	protos, err := LoadProto(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(protos.RPCs) != 1 {
+		t.Fatalf("rpcs = %d, want 1", len(protos.RPCs))
+	}
+	// custom pattern without kind or path is skipped
+	want := []HTTPRule{
+		{Method: "GET", Path: "/v1/products/{id}"},
+		{Method: "HEAD", Path: "/v1/products/{id}"},
+		{Method: "POST", Path: "/v1/{name=ops/*}:cancel"},
+	}
+	if got := protos.RPCs[0].HTTP; !reflect.DeepEqual(got, want) {
+		t.Errorf("httpRules() = %v, want %v", got, want)
+	}
+}
+
+func TestGatewayRoutesInvokeTemplate(t *testing.T) {
+	cfg := Config{ProtoPath: "testdata/proto", InvokeTemplate: "{host}/{repo}/{package}.{service}.{method}"}
+	// without grpc test the invoke template is not used, so {repo} without repository is fine
+	routes, err := GatewayRoutes(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != 1 || routes[0].Method+" "+routes[0].Path != "GET /v1/products/{product_id}" {
+		t.Errorf("GatewayRoutes() = %v", routes)
+	}
+
+	cfg.IntegrationPath = t.TempDir()
+	if _, err := GatewayRoutes(cfg); err == nil {
+		t.Error("GatewayRoutes() with grpc test should fail when {repo} is used without repository")
+	}
+}
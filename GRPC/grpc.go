@@ -0,0 +1,314 @@
+// Package grpc lists grpc endpoint of a service and its integration test into ITSWEEP sheet
+package grpc
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+
+	api "github.com/IndraWirananta/IntegrationTestRelatedScript/API"
+	"github.com/IndraWirananta/IntegrationTestRelatedScript/testcase"
+)
+
+// Config hold every path needed by grpc sweep
+type Config struct {
+	IntegrationPath string   `yaml:"tests"`      // grpc integration test local path
+	ProtoPath       string   `yaml:"proto"`      // grpc proto file or directory (import is followed), or binary FileDescriptorSet
+	RepositoryName  string   `yaml:"repository"` // repository name, replace {repo} in InvokeTemplate
+	InvokeTemplate  string   `yaml:"invoke"`     // url of an rpc in test apiName, default DefaultInvokeTemplate
+	DocumentName    string   `yaml:"output"`     // file name for sheet
+	RestTestsPath   string   `yaml:"restTests"`  // REST integration test local path, its test of grpc-gateway route also cover the rpc
+	Envs            []string `yaml:"envs"`       // env to report, the first env a test has is used
+	Status          []string `yaml:"status"`     // dropdown value of status column
+}
+
+// Run will list every rpc in ProtoPath along with its integration test
+// and save it as excel sheet in DocumentName, grouped per service
+func Run(cfg Config) error {
+	integrationPath := cfg.IntegrationPath
+	documentName := cfg.DocumentName
+
+	// create new excel sheet
+	xlsx := excelize.NewFile()
+	sheet1Name := "Sheet1"
+	xlsx.SetSheetName(xlsx.GetSheetName(1), sheet1Name)
+
+	// create column name
+	xlsx.SetCellValue(sheet1Name, "A1", "Endpoint")
+	xlsx.SetCellValue(sheet1Name, "B1", "Service")
+	xlsx.SetCellValue(sheet1Name, "C1", "Mode")
+	xlsx.SetCellValue(sheet1Name, "D1", "Test Case Name")
+	xlsx.SetCellValue(sheet1Name, "E1", "File Name")
+	xlsx.SetCellValue(sheet1Name, "F1", "Scenario")
+	xlsx.SetCellValue(sheet1Name, "G1", "Expected Response")
+	xlsx.SetCellValue(sheet1Name, "H1", "Request Param")
+	xlsx.SetCellValue(sheet1Name, "I1", "Status")
+	xlsx.SetCellValue(sheet1Name, "J1", "Notes")
+	xlsx.SetCellValue(sheet1Name, "K1", "PIC")
+
+	// add auto filter to column
+	err := xlsx.AutoFilter(sheet1Name, "A1", "K1", "")
+	if err != nil {
+		return err
+	}
+
+	// add data validation for status column
+	dvRange := excelize.NewDataValidation(true)
+	dvRange.Sqref = "I:I"
+	dvRange.SetDropList(cfg.Status)
+	xlsx.AddDataValidation(sheet1Name, dvRange)
+
+	// parse every rpc in protos file (including file it import) or descriptor set
+	parsed, byInvoke, err := loadEndpoints(cfg)
+	if err != nil {
+		return err
+	}
+
+	tests := make(map[*RPC][]sheetTest) // integration test of each rpc
+	var notFound []sheetTest            // integration test whose apiName match no rpc
+	var total int                       // total integration test
+
+	err = testcase.Walk(integrationPath, // will "walk" to every directory and subdirectory in integrationPath
+		func(path string, result *testcase.IntegrationTest, err error) error {
+			if err != nil {
+				fmt.Println(err)
+				return nil
+			}
+
+			// only report the first env in cfg.Envs that the test has
+			structure, ok := result.First(cfg.Envs)
+			if !ok {
+				fmt.Printf("skip %s: no structure for env %v\n", path, cfg.Envs)
+				return nil
+			}
+			total++
+
+			// eg. {host}/function/sampleapp.Sampleapp.GetProductDetail/invoke -> sampleapp.Sampleapp/GetProductDetail
+			rpc, ok := byInvoke[invokeKey(result.ApiName)]
+			if !ok {
+				// apiName has a typo, or the rpc is renamed or removed from the proto
+				fmt.Printf("%s: apiName %s does not match any rpc invoke path\n", path, result.ApiName)
+				notFound = append(notFound, sheetTest{result, structure})
+				return nil
+			}
+			tests[rpc] = append(tests[rpc], sheetTest{result, structure})
+			return nil
+		})
+	if err != nil {
+		log.Println(err)
+	}
+
+	fmt.Println("Got a total of " + strconv.Itoa(total) + " testcases")
+
+	// REST test of route exposed by grpc-gateway also cover the rpc, eg. GET /v1/products/{id}
+	restTests := make(map[*RPC][]sheetTest)
+	if cfg.RestTestsPath != "" {
+		restTests, err = walkRestTests(cfg, parsed)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
+	// sort rpc by service then method, so every rpc of a service is grouped together
+	rpcs := append([]*RPC(nil), parsed.RPCs...)
+	sort.SliceStable(rpcs, func(i, j int) bool {
+		if rpcs[i].ServiceName() != rpcs[j].ServiceName() {
+			return rpcs[i].ServiceName() < rpcs[j].ServiceName()
+		}
+		return rpcs[i].Name < rpcs[j].Name
+	})
+
+	row := 1
+	serviceStart := 2
+	var singleExchange int // streaming endpoint only tested with a single message exchange
+	for i, rpc := range rpcs {
+		endpointStart := row + 1
+
+		// json test can only send one request (variables) and assert one response (responseString),
+		// so tested streaming rpc is flagged, its stream is never covered by more than one message
+		var notes []string
+		if len(tests[rpc]) > 0 && rpc.Mode() != Unary {
+			notes = append(notes, "Streaming, test only cover a single message exchange")
+			singleExchange++
+		}
+		for _, rule := range rpc.HTTP {
+			notes = append(notes, "REST "+rule.String())
+		}
+		for _, test := range tests[rpc] {
+			row++
+			writeTest(xlsx, sheet1Name, row, test)
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("I%d", row), "Live")
+			if len(notes) > 0 {
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("J%d", row), strings.Join(notes, ", "))
+			}
+		}
+		for _, test := range restTests[rpc] {
+			row++
+			writeTest(xlsx, sheet1Name, row, test)
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("I%d", row), "Live")
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("J%d", row), "REST "+strings.ToUpper(test.result.HttpMethod)+" "+test.result.ApiName)
+		}
+
+		// insert endpoint that doesnt has integration test
+		if len(tests[rpc]) == 0 && len(restTests[rpc]) == 0 {
+			row++
+			if rpc.Deprecated {
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("I%d", row), "Wont Do")
+			} else {
+				xlsx.SetCellValue(sheet1Name, fmt.Sprintf("I%d", row), "No TestCase")
+			}
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("J%d", row), rpc.Note())
+		}
+		for r := endpointStart; r <= row; r++ {
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("A%d", r), rpc.Name)
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("B%d", r), rpc.ServiceName())
+			xlsx.SetCellValue(sheet1Name, fmt.Sprintf("C%d", r), rpc.Mode())
+		}
+
+		// merge cell of the same endpoint and of the same service
+		xlsx.MergeCell(sheet1Name, "A"+strconv.Itoa(endpointStart), "A"+strconv.Itoa(row))
+		xlsx.MergeCell(sheet1Name, "C"+strconv.Itoa(endpointStart), "C"+strconv.Itoa(row))
+		if i == len(rpcs)-1 || rpcs[i+1].ServiceName() != rpc.ServiceName() {
+			xlsx.MergeCell(sheet1Name, "B"+strconv.Itoa(serviceStart), "B"+strconv.Itoa(row))
+			serviceStart = row + 1
+		}
+	}
+
+	// insert test that doesnt match any rpc
+	for _, test := range notFound {
+		row++
+		writeTest(xlsx, sheet1Name, row, test)
+		xlsx.SetCellValue(sheet1Name, fmt.Sprintf("A%d", row), notFoundEndpoint)
+		xlsx.SetCellValue(sheet1Name, fmt.Sprintf("B%d", row), "-")
+		xlsx.SetCellValue(sheet1Name, fmt.Sprintf("I%d", row), "Endpoint Need Adjustment")
+		xlsx.SetCellValue(sheet1Name, fmt.Sprintf("J%d", row), "No rpc match "+test.result.ApiName)
+	}
+
+	if singleExchange > 0 {
+		fmt.Println(strconv.Itoa(singleExchange) + " streaming endpoint only tested with a single message exchange")
+	}
+	fmt.Println("Scanned a total of " + strconv.Itoa(len(rpcs)) + " endpoint")
+
+	// list every request field of every rpc and the test case that populate it
+	coverage := newRequestCoverage()
+	for _, rpc := range rpcs {
+		for _, test := range tests[rpc] {
+			variables, err := test.structure.VariablesMap()
+			if err != nil {
+				fmt.Println(test.result.Path, err)
+				continue
+			}
+			coverage.add(parsed, rpc, variables, test.result.QueryName)
+		}
+	}
+	writeFieldSheet(xlsx, parsed, rpcs, coverage, cfg.Status)
+
+	// save created sheet
+	return xlsx.SaveAs(documentName)
+}
+
+// sheetTest is an integration test and the env structure reported in the sheet
+type sheetTest struct {
+	result    *testcase.IntegrationTest
+	structure *testcase.Structure
+}
+
+// writeTest will insert test case column of test in row
+func writeTest(xlsx *excelize.File, sheet string, row int, test sheetTest) {
+	xlsx.SetCellValue(sheet, fmt.Sprintf("D%d", row), test.result.QueryName)
+	xlsx.SetCellValue(sheet, fmt.Sprintf("E%d", row), test.result.FileName())
+	xlsx.SetCellValue(sheet, fmt.Sprintf("G%d", row), test.structure.ResponseCode)
+	xlsx.SetCellValue(sheet, fmt.Sprintf("H%d", row), test.structure.VariablesString())
+}
+
+// loadProtos will parse path as .proto file or directory, anything else is read as binary FileDescriptorSet
+func loadProtos(path string) (*Protos, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() || filepath.Ext(path) == ".proto" {
+		return LoadProto(path)
+	}
+	return LoadDescriptorSet(path)
+}
+
+// loadEndpoints will parse every rpc in cfg.ProtoPath and key it by its invoke path without host, used to match test
+// rpc is unique by its full name (eg. sampleapp.Sampleapp/GetProductDetail), template that map two rpc to the same path is an error
+func loadEndpoints(cfg Config) (*Protos, map[string]*RPC, error) {
+	parsed, err := loadProtos(cfg.ProtoPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// url of every rpc in test apiName, eg. {host}/function/{package}.{service}.{method}/invoke
+	invokeTemplate := cfg.InvokeTemplate
+	if invokeTemplate == "" {
+		invokeTemplate = DefaultInvokeTemplate
+	}
+	if strings.Contains(invokeTemplate, "{repo}") && cfg.RepositoryName == "" {
+		return nil, nil, fmt.Errorf("invoke template %s use {repo} but repository name is not set", invokeTemplate)
+	}
+
+	byInvoke := make(map[string]*RPC)
+	collisions := make(map[string][]string) // full name of every rpc sharing an invoke path
+	var collided []string
+	for _, rpc := range parsed.RPCs {
+		key := invokeKey(rpc.InvokePath(invokeTemplate, cfg.RepositoryName))
+		if first, ok := byInvoke[key]; ok {
+			if len(collisions[key]) == 0 {
+				collided = append(collided, key)
+				collisions[key] = []string{first.FullName()}
+			}
+			collisions[key] = append(collisions[key], rpc.FullName())
+			continue
+		}
+		byInvoke[key] = rpc
+	}
+
+	// eg. template without {package} or {service} map Sampleapp/Check and Health/Check to the same path
+	if len(collided) > 0 {
+		var messages []string
+		for _, key := range collided {
+			messages = append(messages, key+": "+strings.Join(collisions[key], ", "))
+		}
+		return nil, nil, fmt.Errorf("invoke template %s map more than one rpc to the same path, add {package} or {service}\n%s", invokeTemplate, strings.Join(messages, "\n"))
+	}
+	return parsed, byInvoke, nil
+}
+
+// walkRestTests return REST integration test in cfg.RestTestsPath of every rpc, matched by its google.api.http route
+// test of route that is not exposed by grpc-gateway is ignored, it is reported by api sweep
+func walkRestTests(cfg Config, parsed *Protos) (map[*RPC][]sheetTest, error) {
+	routes := make(map[string]api.Route) // keyed by api route key
+	for _, route := range gatewayRoutes(parsed.RPCs) {
+		routes[route.Key()] = route
+	}
+	byName := make(map[string]*RPC)
+	for _, rpc := range parsed.RPCs {
+		byName[rpc.FullName()] = rpc
+	}
+
+	tests := make(map[*RPC][]sheetTest)
+	err := testcase.Walk(cfg.RestTestsPath, func(path string, result *testcase.IntegrationTest, err error) error {
+		if err != nil || result.HttpMethod == "" {
+			return nil // invalid test is reported by api sweep, test without httpMethod is not a REST test
+		}
+		structure, ok := result.First(cfg.Envs)
+		if !ok {
+			return nil
+		}
+		if key, ok := api.FindRoute(routes, result.ApiName, strings.ToUpper(result.HttpMethod)); ok {
+			rpc := byName[routes[key].Handler]
+			tests[rpc] = append(tests[rpc], sheetTest{result, structure})
+		}
+		return nil
+	})
+	return tests, err
+}
@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"strings"
+
+	api "github.com/IndraWirananta/IntegrationTestRelatedScript/API"
+)
+
+// DefaultInvokeTemplate is the url of an rpc in test apiName when not configured
+// eg. {host}/function/sampleapp.Sampleapp.GetProductDetail/invoke
+const DefaultInvokeTemplate = "{host}/function/{package}.{service}.{method}/invoke"
+
+// notFoundEndpoint is the endpoint name of a test whose apiName does not match any rpc
+const notFoundEndpoint = "Not found in proto file"
+
+// InvokePath return url of rpc in template, {package}, {service}, {method} and {repo} is replaced
+// eg. {host}/function/{package}.{service}.{method}/invoke -> {host}/function/sampleapp.Sampleapp.GetProductDetail/invoke
+func (r *RPC) InvokePath(template, repo string) string {
+	return strings.NewReplacer(
+		"{package}", r.Package,
+		"{service}", r.Service,
+		"{method}", r.Name,
+		"{repo}", repo,
+	).Replace(template)
+}
+
+// invokeKey return apiName without host and trailing slash, used to match test with rpc invoke path
+// eg. {host}/function/sampleapp.Sampleapp.GetProductDetail/invoke or http://10.0.0.1:9000/function/... -> /function/...
+func invokeKey(apiName string) string {
+	return strings.TrimSuffix(api.TrimHost(apiName), "/")
+}
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInvokeKey(t *testing.T) {
+	rpc := &RPC{Package: "sampleapp", Service: "Sampleapp", Name: "GetProductDetail"}
+	path := rpc.InvokePath(DefaultInvokeTemplate, "")
+	if path != "{host}/function/sampleapp.Sampleapp.GetProductDetail/invoke" {
+		t.Fatalf("InvokePath() = %q", path)
+	}
+	want := "/function/sampleapp.Sampleapp.GetProductDetail/invoke"
+	for _, apiName := range []string{
+		path,
+		path + "/",
+		"http://10.0.0.1:9000/function/sampleapp.Sampleapp.GetProductDetail/invoke",
+		"{consulHost}/function/sampleapp.Sampleapp.GetProductDetail/invoke/",
+	} {
+		if got := invokeKey(apiName); got != want {
+			t.Errorf("invokeKey(%q) = %q, want %q", apiName, got, want)
+		}
+	}
+}
+
+func TestLoadEndpoints(t *testing.T) {
+	cfg := Config{ProtoPath: "testdata/proto"}
+	_, byInvoke, err := loadEndpoints(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{
+		"/function/common.Health.Check/invoke": "common.Health/Check",
+		"/function/common.Admin.Check/invoke":  "common.Admin/Check",
+	} {
+		if rpc, ok := byInvoke[key]; !ok || rpc.FullName() != want {
+			t.Errorf("byInvoke[%q] = %v, want %s", key, rpc, want)
+		}
+	}
+
+	// without {service}, Health/Check and Admin/Check share the same invoke path
+	cfg.InvokeTemplate = "{host}/function/{package}.{method}/invoke"
+	_, _, err = loadEndpoints(cfg)
+	if err == nil || !strings.Contains(err.Error(), "common.Health/Check, common.Admin/Check") && !strings.Contains(err.Error(), "common.Admin/Check, common.Health/Check") {
+		t.Errorf("loadEndpoints() error = %v, want collision of common.Health/Check and common.Admin/Check", err)
+	}
+
+	cfg.InvokeTemplate = "{host}/{repo}/{package}.{service}.{method}"
+	if _, _, err := loadEndpoints(cfg); err == nil {
+		t.Error("loadEndpoints() should fail when {repo} is used without repository name")
+	}
+}
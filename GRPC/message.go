@@ -0,0 +1,138 @@
+package grpc
+
+import "strings"
+
+// Message is a proto message, eg. message GetProductRequest { int64 id = 1; }
+type Message struct {
+	Name   string // fully qualified, eg. sampleapp.GetProductRequest
+	Fields []*MessageField
+}
+
+// MessageField is a field of a message
+type MessageField struct {
+	Name     string // as declared, eg. product_id
+	JSONName string // lowerCamelCase name used in json, eg. productId
+	Type     string // scalar (eg. int64), or fully qualified message or enum, eg. sampleapp.Filter
+	Key      string // key type of map field, empty if not a map
+	Repeated bool
+	Oneof    string // oneof the field belong to, empty if none
+	scope    string // message the field is declared in, used to resolve Type of .proto file
+}
+
+// Enum is a proto enum, eg. enum Status { UNKNOWN = 0; ACTIVE = 1; }
+type Enum struct {
+	Name   string // fully qualified, eg. sampleapp.Status
+	Values []EnumValue
+}
+
+// EnumValue is a value of an enum
+type EnumValue struct {
+	Name   string
+	Number int
+}
+
+// Field return field of m called name, name can be the declared name or the json name
+func (m *Message) Field(name string) (*MessageField, bool) {
+	for _, f := range m.Fields {
+		if f.Name == name || f.JSONName == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// TypeString return field type in proto notation, eg. repeated sampleapp.Filter or map<string, int32>
+func (f *MessageField) TypeString() string {
+	switch {
+	case f.Key != "":
+		return "map<" + f.Key + ", " + f.Type + ">"
+	case f.Repeated:
+		return "repeated " + f.Type
+	default:
+		return f.Type
+	}
+}
+
+// ValueName return name of enum value in json, either its name (eg. "ACTIVE") or its number (eg. 1)
+func (e *Enum) ValueName(value interface{}) (string, bool) {
+	for _, v := range e.Values {
+		switch value := value.(type) {
+		case string:
+			if v.Name == value {
+				return v.Name, true
+			}
+		case float64:
+			if float64(v.Number) == value {
+				return v.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// addMessage will register message, message already declared is ignored
+func (p *Protos) addMessage(message *Message) {
+	if p.Messages == nil {
+		p.Messages = make(map[string]*Message)
+	}
+	if _, ok := p.Messages[message.Name]; !ok {
+		p.Messages[message.Name] = message
+	}
+}
+
+// addEnum will register enum, enum already declared is ignored
+func (p *Protos) addEnum(enum *Enum) {
+	if p.Enums == nil {
+		p.Enums = make(map[string]*Enum)
+	}
+	if _, ok := p.Enums[enum.Name]; !ok {
+		p.Enums[enum.Name] = enum
+	}
+}
+
+// resolveType return fully qualified message or enum called name, looked up from scope outward
+// eg. Filter in scope sampleapp.ListRequest -> sampleapp.ListRequest.Filter or sampleapp.Filter
+// scalar and type that is not declared (eg. google.protobuf.Timestamp not vendored) is returned as is
+func (p *Protos) resolveType(scope, name string) string {
+	if strings.HasPrefix(name, ".") {
+		return strings.TrimPrefix(name, ".")
+	}
+	for {
+		candidate := name
+		if scope != "" {
+			candidate = scope + "." + name
+		}
+		if _, ok := p.Messages[candidate]; ok {
+			return candidate
+		}
+		if _, ok := p.Enums[candidate]; ok {
+			return candidate
+		}
+		if scope == "" {
+			return name
+		}
+		if i := strings.LastIndexByte(scope, '.'); i != -1 {
+			scope = scope[:i]
+		} else {
+			scope = ""
+		}
+	}
+}
+
+// jsonName return lowerCamelCase json name of a field, eg. product_id -> productId
+func jsonName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, c := range name {
+		if c == '_' {
+			upper = true
+			continue
+		}
+		if upper && 'a' <= c && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(c)
+	}
+	return b.String()
+}
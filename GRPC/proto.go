@@ -0,0 +1,327 @@
+package grpc
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	protoparser "github.com/emicklei/proto"
+)
+
+// Protos is every rpc of a grpc service, built from .proto files or a FileDescriptorSet
+type Protos struct {
+	RPCs     []*RPC
+	Messages map[string]*Message // keyed by fully qualified name, eg. sampleapp.GetProductRequest
+	Enums    map[string]*Enum    // keyed by fully qualified name, eg. sampleapp.Status
+}
+
+// RPC is a method of a grpc service, eg. rpc GetProduct(GetProductRequest) returns (Product)
+type RPC struct {
+	Package         string // proto package, eg. sampleapp
+	Service         string // eg. Sampleapp
+	Name            string // eg. GetProduct
+	Input           string // fully qualified request message, eg. sampleapp.GetProductRequest
+	Output          string // fully qualified response message
+	ClientStreaming bool
+	ServerStreaming bool
+	Deprecated      bool           // option deprecated = true on the rpc or its service
+	Pos             token.Position // where the rpc is declared
+	HTTP            []HTTPRule     // REST route of option (google.api.http), empty if not exposed by grpc-gateway
+}
+
+// Signature return rpc in proto notation, eg. rpc GetProduct(sampleapp.GetProductRequest) returns (stream sampleapp.Product)
+func (r *RPC) Signature() string {
+	input, output := r.Input, r.Output
+	if r.ClientStreaming {
+		input = "stream " + input
+	}
+	if r.ServerStreaming {
+		output = "stream " + output
+	}
+	return fmt.Sprintf("rpc %s(%s) returns (%s)", r.Name, input, output)
+}
+
+// streaming mode of an rpc
+const (
+	Unary           = "Unary"
+	ServerStreaming = "Server Streaming"
+	ClientStreaming = "Client Streaming"
+	BidiStreaming   = "Bidi Streaming"
+)
+
+// Mode return streaming mode of rpc, eg. Server Streaming for rpc List(Req) returns (stream Resp)
+func (r *RPC) Mode() string {
+	switch {
+	case r.ClientStreaming && r.ServerStreaming:
+		return BidiStreaming
+	case r.ClientStreaming:
+		return ClientStreaming
+	case r.ServerStreaming:
+		return ServerStreaming
+	default:
+		return Unary
+	}
+}
+
+// ServiceName return fully qualified service, eg. sampleapp.Sampleapp
+func (r *RPC) ServiceName() string {
+	if r.Package == "" {
+		return r.Service
+	}
+	return r.Package + "." + r.Service
+}
+
+// FullName return fully qualified rpc, eg. sampleapp.Sampleapp/GetProductDetail
+func (r *RPC) FullName() string {
+	return r.ServiceName() + "/" + r.Name
+}
+
+// Note will describe rpc for the sheet, eg. rpc GetProduct(...) returns (...) at app.proto:12
+func (r *RPC) Note() string {
+	note := r.Signature()
+	if r.Pos.Filename != "" {
+		note += fmt.Sprintf(" at %s:%d", filepath.Base(r.Pos.Filename), r.Pos.Line)
+	}
+	if r.Deprecated {
+		note = "Deprecated, " + note
+	}
+	for _, rule := range r.HTTP {
+		note += ", REST " + rule.String()
+	}
+	return note
+}
+
+// add will append rpc, rpc with the same full name is ignored
+func (p *Protos) add(rpc *RPC) {
+	for _, r := range p.RPCs {
+		if r.FullName() == rpc.FullName() {
+			return
+		}
+	}
+	p.RPCs = append(p.RPCs, rpc)
+}
+
+// LoadProto will parse every rpc in path, a .proto file or a directory of them
+// import is followed, resolved from path directory and from the importing file directory up,
+// import that can not be found (eg. google/api/annotations.proto) is skipped
+func LoadProto(path string) (*Protos, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	root := path
+	var files []string
+	if info.IsDir() {
+		err := filepath.Walk(path, func(name string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && filepath.Ext(name) == ".proto" {
+				files = append(files, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		root = filepath.Dir(path)
+		files = append(files, path)
+	}
+
+	l := &protoLoader{root: root, loaded: make(map[string]bool), protos: &Protos{}}
+	for _, name := range files {
+		if err := l.load(name); err != nil {
+			return nil, err
+		}
+	}
+
+	// type is resolved once every file is parsed, message can be declared in an imported file
+	for _, rpc := range l.protos.RPCs {
+		rpc.Input = qualify(rpc.Package, l.protos.resolveType(rpc.Package, rpc.Input))
+		rpc.Output = qualify(rpc.Package, l.protos.resolveType(rpc.Package, rpc.Output))
+	}
+	for _, message := range l.protos.Messages {
+		for _, field := range message.Fields {
+			field.Type = l.protos.resolveType(field.scope, field.Type)
+		}
+	}
+	return l.protos, nil
+}
+
+// protoLoader parse proto file and the file it import, every file is parsed once
+type protoLoader struct {
+	root   string          // import path is resolved from root first
+	loaded map[string]bool // file already parsed, keyed by clean path
+	protos *Protos
+}
+
+// load will parse proto file path and every file it import
+func (l *protoLoader) load(path string) error {
+	path = filepath.Clean(path)
+	if l.loaded[path] {
+		return nil
+	}
+	l.loaded[path] = true
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	parser := protoparser.NewParser(file)
+	parser.Filename(path)
+	def, err := parser.Parse()
+	if err != nil {
+		return err
+	}
+
+	var pkg string
+	var imports []string
+	var services []*protoparser.Service
+	protoparser.Walk(def,
+		protoparser.WithPackage(func(p *protoparser.Package) { pkg = p.Name }),
+		protoparser.WithImport(func(i *protoparser.Import) { imports = append(imports, i.Filename) }),
+		protoparser.WithService(func(s *protoparser.Service) { services = append(services, s) }),
+	)
+
+	for _, service := range services {
+		serviceDeprecated := isDeprecated(service.Elements)
+		for _, element := range service.Elements {
+			rpc, ok := element.(*protoparser.RPC)
+			if !ok {
+				continue
+			}
+			l.protos.add(&RPC{
+				Package:         pkg,
+				Service:         service.Name,
+				Name:            rpc.Name,
+				Input:           rpc.RequestType, // resolved in LoadProto
+				Output:          rpc.ReturnsType,
+				ClientStreaming: rpc.StreamsRequest,
+				ServerStreaming: rpc.StreamsReturns,
+				Deprecated:      serviceDeprecated || isDeprecated(rpc.Elements),
+				Pos:             token.Position{Filename: rpc.Position.Filename, Line: rpc.Position.Line, Column: rpc.Position.Column},
+				HTTP:            httpRules(rpc.Elements),
+			})
+		}
+	}
+
+	for _, element := range def.Elements {
+		switch element := element.(type) {
+		case *protoparser.Message:
+			l.addMessage(pkg, element)
+		case *protoparser.Enum:
+			l.addEnum(pkg, element)
+		}
+	}
+
+	for _, name := range imports {
+		if found, ok := l.resolve(path, name); ok {
+			if err := l.load(found); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addMessage will register message declared in scope (package or parent message) and its nested message and enum
+func (l *protoLoader) addMessage(scope string, m *protoparser.Message) {
+	if m.IsExtend {
+		return
+	}
+	name := m.Name
+	if scope != "" {
+		name = scope + "." + m.Name
+	}
+	message := &Message{Name: name}
+	addField := func(field *protoparser.Field, repeated bool, key, oneof string) {
+		message.Fields = append(message.Fields, &MessageField{
+			Name:     field.Name,
+			JSONName: jsonName(field.Name),
+			Type:     field.Type,
+			Key:      key,
+			Repeated: repeated,
+			Oneof:    oneof,
+			scope:    name,
+		})
+	}
+	for _, element := range m.Elements {
+		switch element := element.(type) {
+		case *protoparser.NormalField:
+			addField(element.Field, element.Repeated, "", "")
+		case *protoparser.MapField:
+			addField(element.Field, false, element.KeyType, "")
+		case *protoparser.Oneof:
+			for _, choice := range element.Elements {
+				if field, ok := choice.(*protoparser.OneOfField); ok {
+					addField(field.Field, false, "", element.Name)
+				}
+			}
+		case *protoparser.Message:
+			l.addMessage(name, element)
+		case *protoparser.Enum:
+			l.addEnum(name, element)
+		}
+	}
+	l.protos.addMessage(message)
+}
+
+// addEnum will register enum declared in scope (package or parent message)
+func (l *protoLoader) addEnum(scope string, e *protoparser.Enum) {
+	enum := &Enum{Name: e.Name}
+	if scope != "" {
+		enum.Name = scope + "." + e.Name
+	}
+	for _, element := range e.Elements {
+		if value, ok := element.(*protoparser.EnumField); ok {
+			enum.Values = append(enum.Values, EnumValue{Name: value.Name, Number: value.Integer})
+		}
+	}
+	l.protos.addEnum(enum)
+}
+
+// resolve return path of import name of file from, eg. sampleapp/v1/product.proto
+func (l *protoLoader) resolve(from, name string) (string, bool) {
+	dirs := []string{l.root}
+	for dir := filepath.Dir(from); ; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
+	}
+	for _, dir := range dirs {
+		candidate := filepath.Join(dir, name)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// isDeprecated will check if elements has option deprecated = true
+func isDeprecated(elements []protoparser.Visitee) bool {
+	for _, element := range elements {
+		if option, ok := element.(*protoparser.Option); ok && option.Name == "deprecated" && option.Constant.Source == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// qualify return fully qualified message name, eg. GetProductRequest in package sampleapp -> sampleapp.GetProductRequest
+// used for message that is not declared in any parsed file, name that is already qualified (eg. google.protobuf.Empty) is kept
+func qualify(pkg, name string) string {
+	if strings.HasPrefix(name, ".") {
+		return strings.TrimPrefix(name, ".")
+	}
+	if pkg == "" || strings.Contains(name, ".") {
+		return name
+	}
+	return pkg + "." + name
+}
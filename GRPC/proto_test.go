@@ -0,0 +1,148 @@
+package grpc
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadProto(t *testing.T) {
+	// a single file, common/paging.proto is resolved from an ancestor directory and google/... import is skipped
+	for _, path := range []string{"testdata/proto/sampleapp/v1/product.proto", "testdata/proto"} {
+		t.Run(path, func(t *testing.T) {
+			protos, err := LoadProto(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, rpc := range protos.RPCs {
+				got = append(got, rpc.FullName()+" "+rpc.Signature())
+			}
+			want := []string{
+				"sampleapp.ProductService/GetProduct rpc GetProduct(sampleapp.GetProductRequest) returns (sampleapp.Product)",
+				"sampleapp.ProductService/ListProduct rpc ListProduct(sampleapp.ListProductRequest) returns (stream sampleapp.Product)",
+				"sampleapp.ProductService/Upload rpc Upload(stream sampleapp.Product) returns (common.Paging)",
+				"common.Health/Check rpc Check(common.Paging) returns (common.Paging)",
+				"common.Admin/Check rpc Check(common.Paging) returns (common.Paging)", // same method in other service is kept
+			}
+			if !sameElements(got, want) {
+				t.Errorf("rpcs\ngot  %q\nwant %q", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadProtoRPC(t *testing.T) {
+	protos, err := LoadProto("testdata/proto/sampleapp/v1/product.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rpcs := make(map[string]*RPC)
+	for _, rpc := range protos.RPCs {
+		rpcs[rpc.FullName()] = rpc
+	}
+
+	get := rpcs["sampleapp.ProductService/GetProduct"]
+	if get == nil {
+		t.Fatal("GetProduct not found")
+	}
+	if filepath.Base(get.Pos.Filename) != "product.proto" || get.Pos.Line != 11 {
+		t.Errorf("GetProduct position = %s:%d, want product.proto:11", get.Pos.Filename, get.Pos.Line)
+	}
+	if get.Deprecated || get.Mode() != Unary {
+		t.Errorf("GetProduct deprecated = %v, mode = %s", get.Deprecated, get.Mode())
+	}
+	if upload := rpcs["sampleapp.ProductService/Upload"]; !upload.Deprecated || upload.Mode() != ClientStreaming {
+		t.Errorf("Upload deprecated = %v, mode = %s", upload.Deprecated, upload.Mode())
+	}
+	if list := rpcs["sampleapp.ProductService/ListProduct"]; list.Mode() != ServerStreaming {
+		t.Errorf("ListProduct mode = %s", list.Mode())
+	}
+}
+
+func TestLoadProtoMessage(t *testing.T) {
+	protos, err := LoadProto("testdata/proto/sampleapp/v1/product.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		message, field string
+		want           MessageField
+	}{
+		{"sampleapp.ListProductRequest", "paging", MessageField{Name: "paging", JSONName: "paging", Type: "common.Paging"}},
+		{"sampleapp.ListProductRequest", "filters", MessageField{Name: "filters", JSONName: "filters", Type: "sampleapp.ListProductRequest.Filter", Repeated: true}},
+		{"sampleapp.ListProductRequest", "labels", MessageField{Name: "labels", JSONName: "labels", Type: "int32", Key: "string"}},
+		{"sampleapp.ListProductRequest", "byName", MessageField{Name: "by_name", JSONName: "byName", Type: "string", Oneof: "lookup"}},
+		{"sampleapp.ListProductRequest", "by_id", MessageField{Name: "by_id", JSONName: "byId", Type: "int64", Oneof: "lookup"}},
+		{"sampleapp.ListProductRequest", "since", MessageField{Name: "since", JSONName: "since", Type: "google.protobuf.Timestamp"}}, // import not found, kept as is
+		{"sampleapp.ListProductRequest.Filter", "kind", MessageField{Name: "kind", JSONName: "kind", Type: "sampleapp.ListProductRequest.Filter.Kind"}},
+		{"sampleapp.ListProductRequest.Filter", "status", MessageField{Name: "status", JSONName: "status", Type: "sampleapp.Status"}},
+		{"common.Paging", "size", MessageField{Name: "size", JSONName: "size", Type: "int32"}},
+	}
+	for _, tt := range tests {
+		m, ok := protos.Messages[tt.message]
+		if !ok {
+			t.Errorf("message %s not found", tt.message)
+			continue
+		}
+		field, ok := m.Field(tt.field)
+		if !ok {
+			t.Errorf("field %s.%s not found", tt.message, tt.field)
+			continue
+		}
+		got := *field
+		got.scope = ""
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("field %s.%s = %+v, want %+v", tt.message, tt.field, got, tt.want)
+		}
+	}
+
+	for name, want := range map[string][]string{
+		"sampleapp.Status":                         {"STATUS_UNSPECIFIED", "ACTIVE", "ARCHIVED"},
+		"sampleapp.ListProductRequest.Filter.Kind": {"KIND_UNSPECIFIED", "PHYSICAL"},
+	} {
+		enum, ok := protos.Enums[name]
+		if !ok {
+			t.Errorf("enum %s not found", name)
+			continue
+		}
+		var got []string
+		for _, v := range enum.Values {
+			got = append(got, v.Name)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("enum %s = %v, want %v", name, got, want)
+		}
+	}
+	if name, ok := protos.Enums["sampleapp.Status"].ValueName(float64(2)); !ok || name != "ARCHIVED" {
+		t.Errorf("ValueName(2) = %q, %v, want ARCHIVED", name, ok)
+	}
+}
+
+func TestLoadProtoMissingFile(t *testing.T) {
+	if _, err := LoadProto("testdata/proto/missing.proto"); err == nil {
+		t.Error("LoadProto(missing) should fail")
+	}
+}
+
+// sameElements will check if a and b has the same elements in any order
+func sameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := make(map[string]int)
+	for _, s := range a {
+		count[s]++
+	}
+	for _, s := range b {
+		count[s]--
+	}
+	for _, n := range count {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,297 @@
+// Package postmanexport create postman collection from api integration test
+package postmanexport
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	postman "github.com/rbretecher/go-postman-collection"
+
+	"github.com/IndraWirananta/IntegrationTestRelatedScript/testcase"
+)
+
+// default postman variable used as host of an env
+// env that is not listed here will use {{host<Env>}}, eg. {{hostCanary}}
+var defaultHosts = map[string]string{
+	"staging":    "{{hostStaging}}",
+	"production": "{{hostProd}}",
+}
+
+// Config hold every option needed to export postman collection
+type Config struct {
+	IntegrationPath string   `yaml:"tests"`       // integration test local path
+	RepositoryName  string   `yaml:"repository"`  // used as collection name, eg. sampleapp -> sampleapp-API
+	FileName        string   `yaml:"output"`      // file name for postman collection
+	IsSuccessOnly   bool     `yaml:"successOnly"` // generate success only if true
+	IgnoreKeys      []string `yaml:"ignoreKeys"`  // apiParamMap keys that are not route param
+	Envs            []string `yaml:"envs"`        // env to export, one folder for each env
+	LocalEnv        string   `yaml:"localEnv"`    // env copied into Local folder with {{localhost}}, empty to skip
+
+	// use hardcoded host instead of postman variable, keyed by env
+	// if host is empty, will use postman variable e.g. {{hostStaging}}
+	// host format : http://sampleapp.service.xxxx.consul
+	Hosts map[string]string `yaml:"hosts"`
+}
+
+// Run will create postman collection from every api integration test in IntegrationPath
+// and save it in FileName
+func Run(cfg Config) error {
+	// file name for postman collection
+	fileName := cfg.FileName
+
+	// collection name and description
+	c := postman.CreateCollection(cfg.RepositoryName+"-API", "Collection scraped from integration test")
+
+	integrationPath := cfg.IntegrationPath
+	isSuccessOnly := cfg.IsSuccessOnly
+
+	// create collection for each env, and one for local
+	folders := make([]*postman.Items, len(cfg.Envs))
+	for i, env := range cfg.Envs {
+		folders[i] = postman.CreateItemGroup(postman.ItemGroup{
+			Name: envTitle(env),
+		})
+	}
+	folderLocal := postman.CreateItemGroup(postman.ItemGroup{
+		Name: "Local",
+	})
+
+	var total int                         // total Integration test processed
+	err := testcase.Walk(integrationPath, // will "walk" to every directory in integrationPath
+		func(path string, result *testcase.IntegrationTest, err error) error {
+			if err != nil {
+				fmt.Println(err)
+				return nil
+			}
+			total++
+
+			for i, env := range cfg.Envs {
+				structure, ok := result.Env(env)
+				if !ok || (isSuccessOnly && structure.ResponseCode != 200) {
+					continue
+				}
+
+				host, defaultHost := hostOf(cfg.Hosts, env)
+				item, apiName, ok := createPostmanItem(result, *structure, host, defaultHost, cfg.IgnoreKeys)
+				if !ok {
+					fmt.Println(result.Path, "unsupported httpMethod", result.HttpMethod)
+					continue
+				}
+				folders[i].AddItem(&item)
+
+				// copy to local collection
+				if strings.EqualFold(env, cfg.LocalEnv) {
+					itemLocal := copyPostmanItem(item, host, "{{localhost}}", apiName)
+					folderLocal.AddItem(&itemLocal)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		log.Println(err)
+	}
+
+	fmt.Println("Got a total of " + strconv.Itoa(total) + " testcases")
+
+	for _, folder := range folders {
+		c.AddItem(folder)
+	}
+	if cfg.LocalEnv != "" {
+		c.AddItem(folderLocal)
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	err = c.Write(file, postman.V210)
+	file.Close()
+	if err != nil {
+		return err
+	}
+
+	// replace nil header with empty header
+	//
+	// in order to abide by postman collection v210 where
+	// header is mandatory, but there are no way to insert empty header
+	// as empty field is removed on postman collection write
+	// so this "barbaric" solution is implemented
+	// where file is read again, and nil header is replaced with empty header
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	myString := string(content)
+	myString = strings.Replace(myString, "\"header\": [\n                            null\n                        ]", "\"header\": []", -1)
+	return ioutil.WriteFile(fileName, []byte(myString), 0644)
+}
+
+// createPostmanItem will create postman request of an integration test for one env
+// will also return apiName with its route param filled, used to copy the item to other host
+// ok is false if http method is not supported
+func createPostmanItem(result *testcase.IntegrationTest, structure testcase.Structure, host, defaultHost string, ignoreKeys []string) (item postman.Items, apiName string, ok bool) {
+	apiName = strings.Replace(result.ApiName, "{host}", "", -1)
+
+	auth := postman.CreateAuth(postman.NoAuth)
+	item = postman.Items{
+		Name: "[" + fmt.Sprintf("%v", structure.ResponseCode) + "] " + result.QueryName,
+		Request: &postman.Request{
+			Auth: auth,
+			URL: &postman.URL{
+				Raw:      host + apiName,
+				Protocol: "http",
+			},
+			Header: []*postman.Header{nil},
+		},
+	}
+
+	switch strings.ToUpper(result.HttpMethod) {
+	case "GET":
+		item.Request.Method = postman.Get
+	case "POST":
+		item.Request.Method = postman.Post
+	case "PATCH":
+		item.Request.Method = postman.Patch
+	case "DELETE":
+		item.Request.Method = postman.Delete
+	case "PUT":
+		item.Request.Method = postman.Put
+	case "HEAD":
+		item.Request.Method = postman.Head
+	case "OPTIONS":
+		item.Request.Method = postman.Options
+	default:
+		return item, apiName, false
+	}
+
+	// handle existing route param if exist
+	routeParams, err := structure.RouteParams(ignoreKeys)
+	if err != nil {
+		fmt.Println(result.Path, err)
+	}
+	if len(routeParams) > 0 {
+		apiName = testcase.FillRoute(apiName, routeParams)
+		item.Request.URL.Raw = host + apiName
+	}
+
+	variables := structure.VariablesString()
+	if item.Request.Method == postman.Get || item.Request.Method == postman.Head {
+		// create or add route param from variable
+		variablesMap, _ := structure.VariablesMap()
+		if len(variablesMap) > 0 {
+			var routeParam string
+			if !strings.Contains(apiName, "?") {
+				routeParam = "?" // if url has no param, add ?
+			} else {
+				routeParam = "&" // if url already has param, add &
+			}
+			for key, data := range variablesMap {
+				routeParam += key + `=` + testcase.FormatValue(data) + `&`
+			}
+			if last := len(routeParam) - 1; last >= 0 && routeParam[last] == '&' {
+				routeParam = routeParam[:last]
+			}
+			item.Request.URL.Raw += strings.Replace(routeParam, "\"", "\\\"", -1)
+		}
+
+		// insert query/variable to postman item query
+		url, _ := url.Parse(item.Request.URL.Raw)
+		query := []*postman.QueryParam{}
+		for key, value := range url.Query() {
+			query = append(query, &postman.QueryParam{
+				Key:   key,
+				Value: strings.Join(value, ", "),
+			})
+		}
+		item.Request.URL.Query = query
+	} else if variables != "{}" && variables != "null" {
+		item.Request.Body = &postman.Body{
+			Mode: "raw",
+			Raw:  variables,
+		}
+	}
+
+	if host != defaultHost { // if not variable, add host info
+		url, _ := url.Parse(item.Request.URL.Raw)
+		item.Request.URL.Host = strings.Split(url.Host, ".")
+		item.Request.URL.Path = strings.FieldsFunc(url.Path, splitFn)
+		item.Request.URL.Port = url.Port()
+	} else {
+		item.Request.URL.Host = []string{defaultHost}
+		path := strings.FieldsFunc(apiName, splitFn)
+		for i, x := range path {
+			if index := strings.Index(x, "?"); index != -1 {
+				path[i] = x[:index]
+			}
+		}
+		item.Request.URL.Path = path
+	}
+	return item, apiName, true
+}
+
+// copyPostmanItem is used to deepcopy postman items
+func copyPostmanItem(copyFrom postman.Items, oldHost, newHost string, apiName string) postman.Items {
+	auth := postman.CreateAuth(postman.NoAuth)
+	newItem := postman.Items{
+		Name: copyFrom.Name,
+		Request: &postman.Request{
+			Method: copyFrom.Request.Method,
+			Auth:   auth,
+			URL: &postman.URL{
+				Raw:      strings.Replace(copyFrom.Request.URL.Raw, oldHost, newHost, 1),
+				Protocol: "http",
+			},
+			Header: []*postman.Header{nil},
+		},
+	}
+	if copyFrom.Request.Body != nil {
+		newItem.Request.Body = &postman.Body{
+			Mode: copyFrom.Request.Body.Mode,
+			Raw:  copyFrom.Request.Body.Raw,
+		}
+	}
+	if copyFrom.Request.URL.Query != nil {
+		newItem.Request.URL.Query = []*postman.QueryParam{}
+		for _, query := range copyFrom.Request.URL.Query {
+			newItem.Request.URL.Query = append(newItem.Request.URL.Query, &postman.QueryParam{Key: query.Key, Value: query.Value})
+		}
+	}
+
+	newItem.Request.URL.Host = []string{newHost}
+	newItem.Request.URL.Path = strings.FieldsFunc(apiName, splitFn)
+
+	return newItem
+}
+
+// hostOf return host of env and its default postman variable
+// host is the default postman variable if not set in hosts
+func hostOf(hosts map[string]string, env string) (host, defaultHost string) {
+	defaultHost, ok := defaultHosts[strings.ToLower(env)]
+	if !ok {
+		defaultHost = "{{host" + envTitle(env) + "}}"
+	}
+	for k, v := range hosts {
+		if strings.EqualFold(k, env) && v != "" {
+			return v, defaultHost
+		}
+	}
+	return defaultHost, defaultHost
+}
+
+// envTitle will capitalize env name, eg. staging -> Staging
+func envTitle(env string) string {
+	if env == "" {
+		return env
+	}
+	return strings.ToUpper(env[:1]) + env[1:]
+}
+
+// same function with string.Split but ignore empty char
+func splitFn(c rune) bool {
+	return c == '/'
+}
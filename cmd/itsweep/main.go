@@ -0,0 +1,524 @@
+// itsweep is a single binary for every integration test sweep tool
+// every flag can also be set in .itsweep.yaml, found from working directory up to root
+//
+// usage:
+//
+//	itsweep api     -tests <dir> -routes <http.go> | -openapi <openapi.yaml> [-router auto] [-out ITSWEEP.xlsx]
+//	itsweep gql     -tests <dir> -schema <schema.graphqls> | -queries <queries.go> -mutations <mutations.go> [-subscriptions <subscriptions.go>] [-out ITSWEEP.xlsx]
+//	itsweep grpc    -tests <dir> -proto <app.proto> [-invoke <url template>] [-repo <name>] [-out ITSWEEP.xlsx]
+//	itsweep postman -tests <dir> -repo <name> [-out <name>-api.json]
+//	itsweep lint    [dir or file ...]
+//	itsweep schema  generate [-out dir] | validate [-kind rest|graphql|grpc] [dir or file ...]
+//	itsweep config
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	api "github.com/IndraWirananta/IntegrationTestRelatedScript/API"
+	gql "github.com/IndraWirananta/IntegrationTestRelatedScript/GQL"
+	grpc "github.com/IndraWirananta/IntegrationTestRelatedScript/GRPC"
+	postmanexport "github.com/IndraWirananta/IntegrationTestRelatedScript/PostmanExport"
+	"github.com/IndraWirananta/IntegrationTestRelatedScript/config"
+	"github.com/IndraWirananta/IntegrationTestRelatedScript/schema"
+	"github.com/IndraWirananta/IntegrationTestRelatedScript/testcase"
+)
+
+// subcommand hold a runnable itsweep subcommand
+type subcommand struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var subcommands = []subcommand{
+	{"api", "list REST endpoint and its integration test", runApi},
+	{"gql", "list graphql queries/mutation/subscription and its integration test", runGql},
+	{"grpc", "list grpc endpoint and its integration test", runGrpc},
+	{"postman", "create postman collection from api integration test", runPostman},
+	{"lint", "check integration test json files, exit non-zero if any issue found", runLint},
+	{"schema", "generate JSON Schema of integration test, or validate test against it", runSchema},
+	{"config", "print the effective " + config.FileName, runConfig},
+}
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, cmd := range subcommands {
+		if cmd.name == os.Args[1] {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				log.Fatal("ERROR ", err.Error())
+			}
+			return
+		}
+	}
+
+	if os.Args[1] != "-h" && os.Args[1] != "-help" && os.Args[1] != "help" {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", os.Args[1])
+	}
+	usage()
+	os.Exit(2)
+}
+
+// usage will print every available subcommand
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: itsweep <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, cmd := range subcommands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, `run "itsweep <command> -h" for command flags`)
+}
+
+func runApi(args []string) error {
+	fs := newFlagSet("api")
+	var cfg api.Config
+	fs.StringVar(&cfg.IntegrationPath, "tests", "", "integration test directory (required)")
+	fs.StringVar(&cfg.ApplicationPath, "routes", "", "app package directory or one of its file, eg. http.go (required without -openapi)")
+	fs.StringVar(&cfg.OpenAPIPath, "openapi", "", "OpenAPI 3 spec of the app, yaml or json (required without -routes)")
+	fs.StringVar(&cfg.Router, "router", "", "web framework of the app: auto, chi, gorilla, gin, echo or nethttp (default auto)")
+	fs.StringVar(&cfg.DocumentName, "out", "", "output sheet file name (default "+config.DefaultDocumentName+")")
+	fs.Var((*listFlag)(&cfg.Envs), "env", "comma separated env to report, the first env a test has is used (default staging,production)")
+	var gateway, grpcTests string
+	fs.StringVar(&gateway, "gateway", "", "grpc proto file or directory, or FileDescriptorSet, whose google.api.http route is linked with its rpc")
+	fs.StringVar(&grpcTests, "grpc-tests", "", "grpc integration test directory, its test also cover the grpc-gateway route of the rpc")
+	fs.Parse(args)
+
+	c, err := loadConfig(fs, map[string]func(*config.Config) string{
+		"tests":      func(c *config.Config) string { return c.Api.Tests },
+		"routes":     func(c *config.Config) string { return c.Api.Routes },
+		"router":     func(c *config.Config) string { return c.Api.Router },
+		"openapi":    func(c *config.Config) string { return c.Api.OpenAPI },
+		"out":        func(c *config.Config) string { return c.Api.Output },
+		"env":        func(c *config.Config) string { return strings.Join(c.Api.Envs, ",") },
+		"gateway":    func(c *config.Config) string { return c.Api.Gateway },
+		"grpc-tests": func(c *config.Config) string { return c.Api.GrpcTests },
+	})
+	if err != nil {
+		return err
+	}
+	if err := required(fs, "tests"); err != nil {
+		return err
+	}
+	if cfg.ApplicationPath == "" && cfg.OpenAPIPath == "" {
+		if err := required(fs, "routes"); err != nil {
+			return fmt.Errorf("%v, or -openapi", err)
+		}
+	}
+	cfg.Status = c.Status
+	cfg.IgnoreKeys = c.IgnoreKeys
+
+	if gateway != "" {
+		cfg.Gateway, err = grpc.GatewayRoutes(grpc.Config{
+			IntegrationPath: grpcTests,
+			ProtoPath:       gateway,
+			RepositoryName:  c.Repository,
+			InvokeTemplate:  c.Grpc.Invoke,
+			Envs:            cfg.Envs,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	printConfig(c, cfg)
+	return api.Run(cfg)
+}
+
+func runGql(args []string) error {
+	fs := newFlagSet("gql")
+	var cfg gql.Config
+	fs.StringVar(&cfg.IntegrationPath, "tests", "", "integration test directory (required)")
+	fs.StringVar(&cfg.SchemaPath, "schema", "", "graphql schema file or directory, eg. ./graph/schema, or introspection result, eg. schema.json (required without -queries and -mutations)")
+	fs.StringVar(&cfg.QueriesPath, "queries", "", "app queries file, eg. queries.go")
+	fs.StringVar(&cfg.MutationPath, "mutations", "", "app mutation file, eg. mutations.go")
+	fs.StringVar(&cfg.SubscriptionPath, "subscriptions", "", "app subscription file, eg. subscriptions.go")
+	fs.StringVar(&cfg.DocumentName, "out", "", "output sheet file name (default "+config.DefaultDocumentName+")")
+	fs.Var((*listFlag)(&cfg.Envs), "env", "comma separated env to report, the first env a test has is used (default staging,production)")
+	fs.Parse(args)
+
+	c, err := loadConfig(fs, map[string]func(*config.Config) string{
+		"tests":         func(c *config.Config) string { return c.Gql.Tests },
+		"schema":        func(c *config.Config) string { return c.Gql.Schema },
+		"queries":       func(c *config.Config) string { return c.Gql.Queries },
+		"mutations":     func(c *config.Config) string { return c.Gql.Mutations },
+		"subscriptions": func(c *config.Config) string { return c.Gql.Subscriptions },
+		"out":           func(c *config.Config) string { return c.Gql.Output },
+		"env":           func(c *config.Config) string { return strings.Join(c.Gql.Envs, ",") },
+	})
+	if err != nil {
+		return err
+	}
+	if err := required(fs, "tests"); err != nil {
+		return err
+	}
+	if cfg.SchemaPath == "" && cfg.QueriesPath == "" && cfg.MutationPath == "" && cfg.SubscriptionPath == "" {
+		if err := required(fs, "schema"); err != nil {
+			return fmt.Errorf("%v, or -queries and -mutations", err)
+		}
+	}
+	cfg.Status = c.Status
+
+	printConfig(c, cfg)
+	return gql.Run(cfg)
+}
+
+func runGrpc(args []string) error {
+	fs := newFlagSet("grpc")
+	var cfg grpc.Config
+	fs.StringVar(&cfg.IntegrationPath, "tests", "", "integration test directory (required)")
+	fs.StringVar(&cfg.ProtoPath, "proto", "", "app proto file or directory, or FileDescriptorSet, eg. app.pb (required)")
+	fs.StringVar(&cfg.RepositoryName, "repo", "", "repository name, eg. sampleapp (required when -invoke use {repo})")
+	fs.StringVar(&cfg.InvokeTemplate, "invoke", "", "url of an rpc in test apiName, {package}, {service}, {method} and {repo} is replaced (default "+grpc.DefaultInvokeTemplate+")")
+	fs.StringVar(&cfg.RestTestsPath, "rest-tests", "", "REST integration test directory, its test of grpc-gateway route also cover the rpc")
+	fs.StringVar(&cfg.DocumentName, "out", "", "output sheet file name (default "+config.DefaultDocumentName+")")
+	fs.Var((*listFlag)(&cfg.Envs), "env", "comma separated env to report, the first env a test has is used (default staging,production)")
+	fs.Parse(args)
+
+	c, err := loadConfig(fs, map[string]func(*config.Config) string{
+		"tests":      func(c *config.Config) string { return c.Grpc.Tests },
+		"proto":      func(c *config.Config) string { return c.Grpc.Proto },
+		"invoke":     func(c *config.Config) string { return c.Grpc.Invoke },
+		"repo":       func(c *config.Config) string { return c.Repository },
+		"out":        func(c *config.Config) string { return c.Grpc.Output },
+		"env":        func(c *config.Config) string { return strings.Join(c.Grpc.Envs, ",") },
+		"rest-tests": func(c *config.Config) string { return c.Grpc.RestTests },
+	})
+	if err != nil {
+		return err
+	}
+	if err := required(fs, "tests", "proto"); err != nil {
+		return err
+	}
+	cfg.Status = c.Status
+
+	printConfig(c, cfg)
+	return grpc.Run(cfg)
+}
+
+func runPostman(args []string) error {
+	fs := newFlagSet("postman")
+	var cfg postmanexport.Config
+	fs.StringVar(&cfg.IntegrationPath, "tests", "", "integration test directory (required)")
+	fs.StringVar(&cfg.RepositoryName, "repo", "", "repository name, used as collection name (required)")
+	fs.StringVar(&cfg.FileName, "out", "", "output collection file name (default <repo>-api.json)")
+	fs.BoolVar(&cfg.IsSuccessOnly, "success-only", false, "only export test case with 200 response code")
+	fs.Var((*listFlag)(&cfg.Envs), "env", "comma separated env to export, one folder for each env (default staging,production)")
+	fs.StringVar(&cfg.LocalEnv, "local-env", "", `env copied into Local folder, "none" to skip (default staging)`)
+	cfg.Hosts = make(map[string]string)
+	fs.Var((mapFlag)(cfg.Hosts), "host", "hardcoded host of an env instead of {{host<Env>}}, eg. staging=http://sampleapp.service.xxxx.consul (repeatable)")
+	fs.Parse(args)
+
+	c, err := loadConfig(fs, map[string]func(*config.Config) string{
+		"tests":        func(c *config.Config) string { return c.Postman.Tests },
+		"repo":         func(c *config.Config) string { return c.Repository },
+		"out":          func(c *config.Config) string { return c.Postman.Output },
+		"success-only": func(c *config.Config) string { return strconv.FormatBool(c.Postman.SuccessOnly) },
+		"env":          func(c *config.Config) string { return strings.Join(c.Postman.Envs, ",") },
+		"local-env":    func(c *config.Config) string { return c.Postman.LocalEnv },
+	})
+	if err != nil {
+		return err
+	}
+	if err := required(fs, "tests", "repo"); err != nil {
+		return err
+	}
+	if cfg.FileName == "" {
+		cfg.FileName = cfg.RepositoryName + "-api.json"
+	}
+	if cfg.LocalEnv == "none" {
+		cfg.LocalEnv = ""
+	}
+	for env, host := range c.Postman.Hosts {
+		if _, ok := cfg.Hosts[env]; !ok {
+			cfg.Hosts[env] = host
+		}
+	}
+	cfg.IgnoreKeys = c.IgnoreKeys
+
+	printConfig(c, cfg)
+	return postmanexport.Run(cfg)
+}
+
+func runLint(args []string) error {
+	fs := newFlagSet("lint")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: itsweep lint [flags] [dir or file ...]")
+		fmt.Fprintln(fs.Output(), "without argument, will lint every tests directory in config")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	c, err := loadConfig(fs, nil)
+	if err != nil {
+		return err
+	}
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		seen := make(map[string]bool)
+		for _, path := range []string{c.Api.Tests, c.Gql.Tests, c.Grpc.Tests, c.Postman.Tests} {
+			if path != "" && !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("lint: no dir or file to lint (or set tests in %s)", config.FileName)
+	}
+
+	var total int // total issue found
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+
+		var issues []testcase.Issue
+		if info.IsDir() {
+			issues, err = testcase.LintDir(path)
+		} else {
+			issues, err = testcase.Lint(path)
+		}
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+		total += len(issues)
+	}
+
+	if total > 0 {
+		return fmt.Errorf("lint: found %d issue", total)
+	}
+	return nil
+}
+
+func runSchema(args []string) error {
+	if len(args) > 0 && args[0] == "generate" {
+		return runSchemaGenerate(args[1:])
+	}
+	if len(args) > 0 && args[0] == "validate" {
+		return runSchemaValidate(args[1:])
+	}
+	fmt.Fprintln(os.Stderr, "usage: itsweep schema generate [-out dir]")
+	fmt.Fprintln(os.Stderr, "       itsweep schema validate [-kind rest|graphql|grpc] [dir or file ...]")
+	return fmt.Errorf("schema: expected generate or validate")
+}
+
+func runSchemaGenerate(args []string) error {
+	fs := flag.NewFlagSet("schema generate", flag.ExitOnError)
+	out := fs.String("out", "./schema/v"+schema.Version, "output directory")
+	fs.Parse(args)
+
+	paths, err := schema.Write(*out)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		fmt.Println("Generated " + path)
+	}
+	return nil
+}
+
+func runSchemaValidate(args []string) error {
+	fs := newFlagSet("schema validate")
+	kindName := fs.String("kind", "", "schema kind: rest, graphql or grpc (default detected from each file)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: itsweep schema validate [flags] [dir or file ...]")
+		fmt.Fprintln(fs.Output(), "without argument, will validate every tests directory in config with the schema of its command")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	c, err := loadConfig(fs, nil)
+	if err != nil {
+		return err
+	}
+
+	var kind schema.Kind
+	if *kindName != "" {
+		if kind, err = schema.ParseKind(*kindName); err != nil {
+			return err
+		}
+	}
+
+	// path to validate with its kind
+	type target struct {
+		path string
+		kind schema.Kind
+	}
+	var targets []target
+	for _, path := range fs.Args() {
+		targets = append(targets, target{path, kind})
+	}
+	if len(targets) == 0 {
+		for _, t := range []target{{c.Api.Tests, schema.Rest}, {c.Gql.Tests, schema.GraphQL}, {c.Grpc.Tests, schema.Grpc}} {
+			if t.path == "" {
+				continue
+			}
+			if kind != "" {
+				t.kind = kind
+			}
+			targets = append(targets, t)
+		}
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("schema validate: no dir or file to validate (or set tests in %s)", config.FileName)
+	}
+
+	v, err := schema.NewValidator()
+	if err != nil {
+		return err
+	}
+
+	var total int // total issue found
+	for _, t := range targets {
+		info, err := os.Stat(t.path)
+		if err != nil {
+			return err
+		}
+
+		var issues []testcase.Issue
+		if info.IsDir() {
+			issues, err = v.ValidateDir(t.kind, t.path)
+		} else {
+			issues, err = v.Validate(t.kind, t.path)
+		}
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+		total += len(issues)
+	}
+
+	if total > 0 {
+		return fmt.Errorf("schema validate: found %d issue", total)
+	}
+	return nil
+}
+
+func runConfig(args []string) error {
+	fs := newFlagSet("config")
+	fs.Parse(args)
+
+	c, err := loadConfig(fs, nil)
+	if err != nil {
+		return err
+	}
+	if c.Path == "" {
+		fmt.Println("# no " + config.FileName + " found, using default")
+	} else {
+		fmt.Println("# " + c.Path)
+	}
+	fmt.Print(c.String())
+	return nil
+}
+
+// newFlagSet will create flag set with -config flag that every subcommand has
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.String("config", "", "config file (default "+config.FileName+" found from working directory up)")
+	return fs
+}
+
+// loadConfig will load config from -config flag or working directory
+// then every flag that is not set from command line will use its config value
+func loadConfig(fs *flag.FlagSet, fromConfig map[string]func(*config.Config) string) (*config.Config, error) {
+	c, err := config.Discover(fs.Lookup("config").Value.String())
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	for name, value := range fromConfig {
+		if v := value(c); !set[name] && v != "" {
+			if err := fs.Set(name, v); err != nil {
+				return nil, fmt.Errorf("%s: invalid %s in %s: %v", fs.Name(), name, c.Path, err)
+			}
+		}
+	}
+	return c, nil
+}
+
+// printConfig will print the effective configuration of a subcommand
+func printConfig(c *config.Config, cfg interface{}) {
+	if c.Path != "" {
+		fmt.Println("Using config " + c.Path)
+	}
+	content, err := yaml.Marshal(cfg)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	fmt.Print(string(content))
+}
+
+// required will return error if one of the flag is empty
+// either from command line or config
+func required(fs *flag.FlagSet, names ...string) error {
+	for _, name := range names {
+		if fs.Lookup(name).Value.String() == "" {
+			return fmt.Errorf("%s: missing -%s flag (or set it in %s)", fs.Name(), name, config.FileName)
+		}
+	}
+	return nil
+}
+
+// listFlag is comma separated flag value, eg. -env staging,production
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = nil
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+// mapFlag is repeatable key=value flag value, eg. -host staging=http://... -host production=http://...
+type mapFlag map[string]string
+
+func (m mapFlag) String() string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (m mapFlag) Set(value string) error {
+	i := strings.Index(value, "=")
+	if i <= 0 {
+		return fmt.Errorf("%q is not key=value", value)
+	}
+	m[value[:i]] = value[i+1:]
+	return nil
+}
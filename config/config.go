@@ -0,0 +1,213 @@
+// Package config load .itsweep.yaml, the per repository setup of every sweep tool
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the config file name searched from working directory up to root
+const FileName = ".itsweep.yaml"
+
+// DefaultDocumentName is the file name for sheet when not set anywhere
+const DefaultDocumentName = "./ITSWEEP.xlsx"
+
+// DefaultStatus is the dropdown value of status column when not set in config
+var DefaultStatus = []string{"Live", "On Progress", "Not Yet", "Pending", "No TestCase", "Not Checked", "Need Fix", "Wont Do", "Endpoint Need Adjustment"}
+
+// DefaultEnvs is the env to report or export when not set in config
+// sheet use the first env a test has, postman create a folder for each env
+var DefaultEnvs = []string{"staging", "production"}
+
+// DefaultLocalEnv is the env copied into postman Local folder when not set in config
+const DefaultLocalEnv = "staging"
+
+// DefaultIgnoreKeys is apiParamMap keys that are not route param when not set in config
+var DefaultIgnoreKeys = []string{"host", "consulHost"}
+
+// Config hold the content of .itsweep.yaml
+// Tests and Output on root level is used by every subcommand that doesnt set its own
+type Config struct {
+	Path string `yaml:"-"` // where config is loaded from, empty if no config found
+
+	Tests      string   `yaml:"tests,omitempty"`      // integration test root
+	Output     string   `yaml:"output,omitempty"`     // file name for sheet
+	Repository string   `yaml:"repository,omitempty"` // repository name
+	Status     []string `yaml:"status,omitempty"`     // dropdown value of status column
+	IgnoreKeys []string `yaml:"ignoreKeys,omitempty"` // apiParamMap keys that are not route param
+	Envs       []string `yaml:"envs,omitempty"`       // env to report or export
+
+	Api     Api     `yaml:"api,omitempty"`
+	Gql     Gql     `yaml:"gql,omitempty"`
+	Grpc    Grpc    `yaml:"grpc,omitempty"`
+	Postman Postman `yaml:"postman,omitempty"`
+}
+
+// Api hold config of api subcommand
+type Api struct {
+	Tests   string   `yaml:"tests,omitempty"`
+	Output  string   `yaml:"output,omitempty"`
+	Envs    []string `yaml:"envs,omitempty"`
+	Routes  string   `yaml:"routes,omitempty"`  // app package directory or one of its file (eg. http.go)
+	Router  string   `yaml:"router,omitempty"`  // web framework adapter, eg. chi, gorilla, gin, echo, nethttp (default auto)
+	OpenAPI string   `yaml:"openapi,omitempty"` // OpenAPI 3 spec, used with or instead of routes
+
+	Gateway   string `yaml:"gateway,omitempty"`   // grpc proto whose google.api.http route is linked with its rpc, not set by default
+	GrpcTests string `yaml:"grpcTests,omitempty"` // grpc integration test of the gateway rpc, not set by default
+}
+
+// Gql hold config of gql subcommand
+type Gql struct {
+	Tests         string   `yaml:"tests,omitempty"`
+	Output        string   `yaml:"output,omitempty"`
+	Envs          []string `yaml:"envs,omitempty"`
+	Schema        string   `yaml:"schema,omitempty"`        // graphql schema file or directory
+	Queries       string   `yaml:"queries,omitempty"`       // app queries file
+	Mutations     string   `yaml:"mutations,omitempty"`     // app mutation file
+	Subscriptions string   `yaml:"subscriptions,omitempty"` // app subscription file
+}
+
+// Grpc hold config of grpc subcommand
+type Grpc struct {
+	Tests  string   `yaml:"tests,omitempty"`
+	Output string   `yaml:"output,omitempty"`
+	Envs   []string `yaml:"envs,omitempty"`
+	Proto  string   `yaml:"proto,omitempty"`  // app proto file or directory, or FileDescriptorSet
+	Invoke string   `yaml:"invoke,omitempty"` // url of an rpc in test apiName, eg. {host}/function/{package}.{service}.{method}/invoke
+
+	RestTests string `yaml:"restTests,omitempty"` // REST integration test of grpc-gateway route, not set by default
+}
+
+// Postman hold config of postman subcommand
+type Postman struct {
+	Tests       string            `yaml:"tests,omitempty"`
+	Output      string            `yaml:"output,omitempty"`
+	Envs        []string          `yaml:"envs,omitempty"`
+	SuccessOnly bool              `yaml:"successOnly,omitempty"`
+	LocalEnv    string            `yaml:"localEnv,omitempty"` // env copied into Local folder, "none" to skip
+	Hosts       map[string]string `yaml:"hosts,omitempty"`    // hardcoded host keyed by env
+}
+
+// Find will walk up from dir until FileName is found
+// return empty string if there is no config until root
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, FileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load will read config from path
+// relative path inside config is resolved against the config directory
+func Load(path string) (*Config, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return nil, &os.PathError{Op: "parse", Path: path, Err: err}
+	}
+	cfg.Path = path
+
+	dir := filepath.Dir(path)
+	for _, p := range []*string{
+		&cfg.Tests, &cfg.Output,
+		&cfg.Api.Tests, &cfg.Api.Output, &cfg.Api.Routes, &cfg.Api.OpenAPI, &cfg.Api.Gateway, &cfg.Api.GrpcTests,
+		&cfg.Gql.Tests, &cfg.Gql.Output, &cfg.Gql.Schema, &cfg.Gql.Queries, &cfg.Gql.Mutations, &cfg.Gql.Subscriptions,
+		&cfg.Grpc.Tests, &cfg.Grpc.Output, &cfg.Grpc.Proto, &cfg.Grpc.RestTests,
+		&cfg.Postman.Tests, &cfg.Postman.Output,
+	} {
+		if *p != "" && !filepath.IsAbs(*p) {
+			*p = filepath.Join(dir, *p)
+		}
+	}
+
+	cfg.setDefault()
+	return cfg, nil
+}
+
+// Discover will load config from path if not empty, otherwise
+// will find config from working directory
+// if there is no config, will return default config
+func Discover(path string) (*Config, error) {
+	if path == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		path, err = Find(wd)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if path == "" {
+		cfg := &Config{}
+		cfg.setDefault()
+		return cfg, nil
+	}
+	return Load(path)
+}
+
+// setDefault will fill value that is not set in config with its default
+// subcommand Tests and Output will fallback to root level value
+func (c *Config) setDefault() {
+	if len(c.Status) == 0 {
+		c.Status = DefaultStatus
+	}
+	if c.IgnoreKeys == nil {
+		c.IgnoreKeys = DefaultIgnoreKeys
+	}
+	if c.Output == "" {
+		c.Output = DefaultDocumentName
+	}
+	if len(c.Envs) == 0 {
+		c.Envs = DefaultEnvs
+	}
+	for _, p := range []*[]string{&c.Api.Envs, &c.Gql.Envs, &c.Grpc.Envs, &c.Postman.Envs} {
+		if len(*p) == 0 {
+			*p = c.Envs
+		}
+	}
+	if c.Postman.LocalEnv == "" {
+		c.Postman.LocalEnv = DefaultLocalEnv
+	}
+	for _, p := range []*string{&c.Api.Tests, &c.Gql.Tests, &c.Grpc.Tests, &c.Postman.Tests} {
+		if *p == "" {
+			*p = c.Tests
+		}
+	}
+	for _, p := range []*string{&c.Api.Output, &c.Gql.Output, &c.Grpc.Output} {
+		if *p == "" {
+			*p = c.Output
+		}
+	}
+}
+
+// String will return config as yaml
+func (c *Config) String() string {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return err.Error()
+	}
+	return string(content)
+}
@@ -0,0 +1,16 @@
+module github.com/IndraWirananta/IntegrationTestRelatedScript
+
+go 1.22
+
+require (
+	github.com/360EntSecGroup-Skylar/excelize v1.4.1
+	github.com/emicklei/proto v1.13.2
+	github.com/rbretecher/go-postman-collection v0.9.0
+	github.com/santhosh-tekuri/jsonschema/v6 v6.0.2
+	github.com/vektah/gqlparser/v2 v2.5.16
+	golang.org/x/text v0.14.0
+	google.golang.org/protobuf v1.34.2
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826 // indirect
@@ -0,0 +1,201 @@
+// Package schema generate JSON Schema (draft 2020-12) of integration test file
+// and validate integration test against it
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/IndraWirananta/IntegrationTestRelatedScript/testcase"
+)
+
+// Version of the integration test file format
+// bump it whenever a field is added or changed, schema is shipped in schema/v<Version>
+const Version = "1"
+
+// BaseURL is where the shipped schema can be downloaded by editor
+const BaseURL = "https://raw.githubusercontent.com/IndraWirananta/IntegrationTestRelatedScript/master/schema/v" + Version + "/"
+
+// Kind is the kind of integration test
+type Kind string
+
+const (
+	Rest    Kind = "rest"    // api integration test, has httpMethod and apiName
+	GraphQL Kind = "graphql" // gql integration test, has query
+	Grpc    Kind = "grpc"    // grpc integration test, apiName is the invoke path
+)
+
+// Kinds is every kind that has a schema
+var Kinds = []Kind{Rest, GraphQL, Grpc}
+
+// ParseKind will return Kind of name, eg. "rest"
+func ParseKind(name string) (Kind, error) {
+	for _, kind := range Kinds {
+		if string(kind) == name {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown schema kind %q, expected rest, graphql or grpc", name)
+}
+
+// FileName return file name of the schema, eg. rest.schema.json
+func (k Kind) FileName() string {
+	return string(k) + ".schema.json"
+}
+
+// URL return $id of the schema
+func (k Kind) URL() string {
+	return BaseURL + k.FileName()
+}
+
+// httpMethodPattern return regex matching every allowed http method in any case, eg. ^([Gg][Ee][Tt]|...)$
+// same as what itsweep lint accept, see testcase.HttpMethods
+// character class is used instead of (?i) so the pattern also work in editor (ECMA 262 regex)
+func httpMethodPattern() string {
+	var methods []string
+	for _, method := range testcase.HttpMethods {
+		var b strings.Builder
+		for _, c := range method {
+			b.WriteString("[" + string(c) + strings.ToLower(string(c)) + "]")
+		}
+		methods = append(methods, b.String())
+	}
+	return "^(" + strings.Join(methods, "|") + ")$"
+}
+
+// Generate will return JSON Schema of kind
+func Generate(kind Kind) map[string]interface{} {
+	properties := map[string]interface{}{
+		"queryName": map[string]interface{}{
+			"type":        "string",
+			"minLength":   1,
+			"description": "test case name, shown in sheet and postman",
+		},
+		"structure": map[string]interface{}{
+			"type":        "array",
+			"minItems":    1,
+			"items":       map[string]interface{}{"$ref": "#/$defs/structure"},
+			"description": "test case for each env, env must be unique",
+		},
+	}
+	required := []string{"queryName", "structure"}
+	title := ""
+
+	switch kind {
+	case Rest:
+		title = "REST integration test"
+		properties["httpMethod"] = map[string]interface{}{
+			"type":        "string",
+			"pattern":     httpMethodPattern(),
+			"examples":    testcase.HttpMethods,
+			"description": "http method of the endpoint, case insensitive",
+		}
+		properties["apiName"] = map[string]interface{}{
+			"type":        "string",
+			"minLength":   1,
+			"description": "endpoint url, {param} is replaced by apiParamMap, eg. {host}/item/{id}",
+			"examples":    []string{"{host}/item/{id}"},
+		}
+		required = append(required, "httpMethod", "apiName")
+	case GraphQL:
+		title = "GraphQL integration test"
+		properties["query"] = map[string]interface{}{
+			"type":        "string",
+			"minLength":   1,
+			"description": "graphql document sent to the service, variables is taken from structure",
+		}
+		required = append(required, "query")
+	case Grpc:
+		title = "gRPC integration test"
+		properties["httpMethod"] = map[string]interface{}{
+			"type":        "string",
+			"pattern":     httpMethodPattern(),
+			"examples":    testcase.HttpMethods,
+			"description": "http method used to invoke the rpc, case insensitive",
+		}
+		properties["apiName"] = map[string]interface{}{
+			"type":        "string",
+			"minLength":   1,
+			"description": "invoke path of the rpc",
+			"examples":    []string{"{host}/function/sampleapp.Sampleapp.GetProductDetail/invoke"},
+		}
+		required = append(required, "apiName")
+	}
+
+	return map[string]interface{}{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"$id":         kind.URL(),
+		"title":       title,
+		"description": "integration test file format v" + Version,
+		"type":        "object",
+		"properties":  properties,
+		"required":    required,
+		"$defs": map[string]interface{}{
+			"structure": structure(kind),
+		},
+	}
+}
+
+// structure return schema of one structure item
+func structure(kind Kind) map[string]interface{} {
+	variables := map[string]interface{}{
+		"type":        []string{"object", "null"},
+		"description": "request body, or query param for GET request",
+	}
+	switch kind {
+	case GraphQL:
+		variables["description"] = "graphql variables"
+	case Grpc:
+		variables["description"] = "rpc request message"
+	}
+
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"env": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"description": "env of this test case",
+				"examples":    []string{"staging", "production", "local", "canary"},
+			},
+			"responseCode": map[string]interface{}{
+				"type":        "integer",
+				"description": "expected response code",
+			},
+			"apiParamMap": map[string]interface{}{
+				"type":        []string{"object", "null"},
+				"description": "value of every {param} in apiName, eg. host and route param",
+			},
+			"variables": variables,
+			"responseString": map[string]interface{}{
+				"description": "expected response body",
+			},
+		},
+		"required": []string{"env"},
+	}
+}
+
+// Write will write schema of every kind into dir
+func Write(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, kind := range Kinds {
+		content, err := json.MarshalIndent(Generate(kind), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(dir, kind.FileName())
+		if err := ioutil.WriteFile(path, append(content, '\n'), 0644); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
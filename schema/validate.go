@@ -0,0 +1,132 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/IndraWirananta/IntegrationTestRelatedScript/testcase"
+)
+
+// used to print validation error message
+var printer = message.NewPrinter(language.English)
+
+// Validator check integration test file against schema of its kind
+type Validator struct {
+	schemas map[Kind]*jsonschema.Schema
+}
+
+// NewValidator will compile schema of every kind
+func NewValidator() (*Validator, error) {
+	c := jsonschema.NewCompiler()
+	for _, kind := range Kinds {
+		// round trip the schema so every value has the type jsonschema expect
+		content, err := json.Marshal(Generate(kind))
+		if err != nil {
+			return nil, err
+		}
+		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		if err := c.AddResource(kind.URL(), doc); err != nil {
+			return nil, err
+		}
+	}
+
+	v := &Validator{schemas: make(map[Kind]*jsonschema.Schema)}
+	for _, kind := range Kinds {
+		sch, err := c.Compile(kind.URL())
+		if err != nil {
+			return nil, err
+		}
+		v.schemas[kind] = sch
+	}
+	return v, nil
+}
+
+// DetectKind will guess kind of integration test from its content
+// test with query is graphql, test with apiName ending in /invoke is grpc, otherwise rest
+func DetectKind(content []byte) Kind {
+	var test testcase.IntegrationTest
+	json.Unmarshal(content, &test)
+	switch {
+	case test.Query != "":
+		return GraphQL
+	case strings.HasSuffix(test.ApiName, "/invoke"):
+		return Grpc
+	default:
+		return Rest
+	}
+}
+
+// Validate will check integration test file in path against schema of kind
+// if kind is empty, kind is detected from the file content
+func (v *Validator) Validate(kind Kind, path string) ([]testcase.Issue, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(content))
+	if err != nil {
+		// invalid json is reported by lint with its position
+		return testcase.LintContent(path, content), nil
+	}
+	if kind == "" {
+		kind = DetectKind(content)
+	}
+
+	err = v.schemas[kind].Validate(doc)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	idx := testcase.NewIndex(content)
+	var issues []testcase.Issue
+	for _, leaf := range leaves(validationErr) {
+		pointer := ""
+		for _, token := range leaf.InstanceLocation {
+			pointer += "/" + testcase.PointerToken(token)
+		}
+		issues = append(issues, testcase.Issue{
+			Path:     path,
+			Position: idx.Lookup(content, pointer),
+			Message:  string(kind) + " schema: " + strings.TrimPrefix(pointer+": ", ": ") + leaf.ErrorKind.LocalizedString(printer),
+		})
+	}
+	return issues, nil
+}
+
+// ValidateDir will Validate every json file in root
+func (v *Validator) ValidateDir(kind Kind, root string) ([]testcase.Issue, error) {
+	var issues []testcase.Issue
+	err := testcase.WalkFiles(root, func(path string) error {
+		found, err := v.Validate(kind, path)
+		if err != nil {
+			return err
+		}
+		issues = append(issues, found...)
+		return nil
+	})
+	return issues, err
+}
+
+// leaves return the deepest validation error, which has the most specific message
+func leaves(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	var result []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		result = append(result, leaves(cause)...)
+	}
+	return result
+}
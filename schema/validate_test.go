@@ -0,0 +1,85 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestShippedSchema(t *testing.T) {
+	for _, kind := range Kinds {
+		shipped, err := ioutil.ReadFile(filepath.Join("v"+Version, kind.FileName()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		generated, err := json.MarshalIndent(Generate(kind), "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(shipped) != string(generated)+"\n" {
+			t.Errorf("v%s/%s is out of date, run itsweep schema generate", Version, kind.FileName())
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	v, err := NewValidator()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := v.ValidateDir("", "testdata/valid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("valid test has issue %v", issues)
+	}
+
+	issues, err = v.Validate(Rest, "testdata/invalid/get_item.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+	var got []string
+	for _, issue := range issues {
+		// keep the pointer only, message is from jsonschema
+		message := strings.SplitN(issue.Message, ": ", 3)
+		got = append(got, fmt.Sprintf("%d:%d: %s", issue.Line, issue.Column, strings.Join(message[:2], ": ")))
+	}
+	want := []string{
+		"2:3: rest schema: /queryName",
+		"3:3: rest schema: /httpMethod",
+		"8:7: rest schema: /structure/0/responseCode",
+		"11:5: rest schema: /structure/1",
+		"12:7: rest schema: /structure/1/apiParamMap",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("issues\ngot  %q\nwant %q", got, want)
+	}
+}
+
+func TestDetectKind(t *testing.T) {
+	tests := []struct {
+		file string
+		want Kind
+	}{
+		{"testdata/valid/get_item.json", Rest},
+		{"testdata/valid/get_product.json", GraphQL},
+		{"testdata/valid/get_product_detail.json", Grpc},
+	}
+	for _, tt := range tests {
+		content, err := ioutil.ReadFile(tt.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := DetectKind(content); got != tt.want {
+			t.Errorf("DetectKind(%s) = %s, want %s", tt.file, got, tt.want)
+		}
+	}
+}
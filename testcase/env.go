@@ -0,0 +1,41 @@
+package testcase
+
+import "strings"
+
+// Env return structure of env, env name is case insensitive
+// ok is false if the test has no structure for env
+func (t *IntegrationTest) Env(env string) (*Structure, bool) {
+	for i := range t.Structure {
+		if strings.EqualFold(t.Structure[i].Env, env) {
+			return &t.Structure[i], true
+		}
+	}
+	return nil, false
+}
+
+// Envs return env name of every structure in file order
+func (t *IntegrationTest) Envs() []string {
+	envs := make([]string, 0, len(t.Structure))
+	for _, s := range t.Structure {
+		envs = append(envs, s.Env)
+	}
+	return envs
+}
+
+// First return structure of the first env in envs that the test has
+// eg. envs [staging, production] will use production if test has no staging
+// if envs is empty, will return the first structure in file
+func (t *IntegrationTest) First(envs []string) (*Structure, bool) {
+	if len(envs) == 0 {
+		if len(t.Structure) == 0 {
+			return nil, false
+		}
+		return &t.Structure[0], true
+	}
+	for _, env := range envs {
+		if s, ok := t.Env(env); ok {
+			return s, true
+		}
+	}
+	return nil, false
+}
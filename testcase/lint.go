@@ -0,0 +1,232 @@
+package testcase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// HttpMethods is every http method allowed in httpMethod, case insensitive
+var HttpMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// used to find route param placeholder in apiName, eg. {host}/item/{id}
+var placeholderRegex = regexp.MustCompile(`\{([^{}/?&=]+)\}`)
+
+// Issue is a problem found by Lint in an integration test file
+type Issue struct {
+	Path string
+	Position
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", i.Path, i.Line, i.Column, i.Message)
+}
+
+// Lint will check integration test file in path and return every issue found
+// invalid json syntax will only return one issue for the json error
+func Lint(path string) ([]Issue, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LintContent(path, content), nil
+}
+
+// LintContent is Lint for file content that is already read
+func LintContent(path string, content []byte) []Issue {
+	idx := NewIndex(content)
+	var issues []Issue
+	report := func(pointer string, format string, a ...interface{}) {
+		issues = append(issues, Issue{
+			Path:     path,
+			Position: idx.Lookup(content, pointer),
+			Message:  fmt.Sprintf(format, a...),
+		})
+	}
+
+	var test IntegrationTest
+	invalid := make(map[string]bool) // pointer of field with a wrong type, it is not reported as missing
+	if err := json.Unmarshal(content, &test); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			// Offset is right after the invalid character
+			offset := syntaxErr.Offset - 1
+			if offset < 0 {
+				offset = 0
+			}
+			issues = append(issues, Issue{Path: path, Position: positionOf(content, offset), Message: "invalid json: " + syntaxErr.Error()})
+			return issues
+		case errors.As(err, &typeErr):
+			// json only return the first type error, decode it generically so every problem is reported
+			var raw map[string]interface{}
+			if err := json.Unmarshal(content, &raw); err != nil {
+				report("", "invalid json: %v", err)
+				return issues
+			}
+			test = decodeLenient(raw, func(pointer string, format string, a ...interface{}) {
+				invalid[pointer] = true
+				report(pointer, format, a...)
+			})
+		default:
+			report("", "invalid json: %v", err)
+			return issues
+		}
+	}
+
+	if test.QueryName == "" && !invalid["/queryName"] {
+		report("", "missing queryName")
+	}
+
+	// gql test only need query, api and grpc test need apiName and httpMethod
+	if test.Query == "" && !invalid["/query"] {
+		if test.ApiName == "" && !invalid["/apiName"] {
+			report("", "missing apiName")
+		}
+		if test.HttpMethod == "" {
+			if !invalid["/httpMethod"] {
+				report("", "missing httpMethod")
+			}
+		} else if !isHttpMethod(test.HttpMethod) {
+			report("/httpMethod", "unknown httpMethod %q, expected one of %s", test.HttpMethod, strings.Join(HttpMethods, ", "))
+		}
+	}
+
+	if len(test.Structure) == 0 {
+		if !invalid["/structure"] {
+			report("/structure", "empty structure")
+		}
+		return issues
+	}
+
+	placeholders := placeholderRegex.FindAllStringSubmatch(test.ApiName, -1)
+	seen := make(map[string]int)
+	for i, s := range test.Structure {
+		pointer := "/structure/" + strconv.Itoa(i)
+		env := strings.ToLower(s.Env)
+		if env == "" {
+			if !invalid[pointer] && !invalid[pointer+"/env"] {
+				report(pointer, "structure[%d] missing env", i)
+			}
+		} else if first, ok := seen[env]; ok {
+			report(pointer+"/env", "duplicated env %q, already declared in structure[%d]", s.Env, first)
+		} else {
+			seen[env] = i
+		}
+
+		params, err := s.Params()
+		if err != nil {
+			report(pointer+"/apiParamMap", "apiParamMap is not an object: %v", err)
+			continue
+		}
+		for _, p := range placeholders {
+			if _, ok := params[p[1]]; !ok {
+				report(pointer, "apiName placeholder {%s} has no apiParamMap key in env %q", p[1], s.Env)
+			}
+		}
+	}
+	return issues
+}
+
+// LintDir will Lint every json file in root
+func LintDir(root string) ([]Issue, error) {
+	var issues []Issue
+	err := WalkFiles(root, func(path string) error {
+		found, err := Lint(path)
+		if err != nil {
+			return err
+		}
+		issues = append(issues, found...)
+		return nil
+	})
+	return issues, err
+}
+
+// isHttpMethod will check if method is one of HttpMethods, case insensitive
+func isHttpMethod(method string) bool {
+	for _, m := range HttpMethods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeLenient will build test from generically decoded json, field with a wrong type is reported and left empty
+func decodeLenient(raw map[string]interface{}, report func(pointer string, format string, a ...interface{})) IntegrationTest {
+	str := func(m map[string]interface{}, key, pointer string) string {
+		v, ok := m[key]
+		if !ok || v == nil {
+			return ""
+		}
+		s, ok := v.(string)
+		if !ok {
+			// eg. /structure/0/env -> structure.0.env, same as json type error
+			field := strings.Replace(strings.TrimPrefix(pointer+"/"+key, "/"), "/", ".", -1)
+			report(pointer+"/"+key, "invalid json: %s must be string, got %s", field, jsonType(v))
+		}
+		return s
+	}
+
+	var test IntegrationTest
+	test.QueryName = str(raw, "queryName", "")
+	test.Query = str(raw, "query", "")
+	test.HttpMethod = str(raw, "httpMethod", "")
+	test.ApiName = str(raw, "apiName", "")
+
+	structure, ok := raw["structure"].([]interface{})
+	if !ok {
+		if v, exist := raw["structure"]; exist && v != nil {
+			report("/structure", "invalid json: structure must be array, got %s", jsonType(v))
+		}
+		return test
+	}
+	for i, item := range structure {
+		pointer := "/structure/" + strconv.Itoa(i)
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			report(pointer, "invalid json: structure.%d must be object, got %s", i, jsonType(item))
+			test.Structure = append(test.Structure, Structure{}) // keep index of the next structure
+			continue
+		}
+		s := Structure{Env: str(m, "env", pointer)}
+		if v, ok := m["responseCode"]; ok && v != nil {
+			if code, ok := v.(float64); ok && code == float64(int(code)) {
+				s.ResponseCode = int(code)
+			} else {
+				report(pointer+"/responseCode", "invalid json: structure.%d.responseCode must be int, got %s", i, jsonType(v))
+			}
+		}
+		for key, field := range map[string]*json.RawMessage{"apiParamMap": &s.ApiParamMap, "variables": &s.Variables, "responseString": &s.ResponseString} {
+			if v, ok := m[key]; ok {
+				*field, _ = json.Marshal(v)
+			}
+		}
+		test.Structure = append(test.Structure, s)
+	}
+	return test
+}
+
+// jsonType return json type name of a generically decoded value
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return "null"
+	}
+}
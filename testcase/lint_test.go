@@ -0,0 +1,119 @@
+package testcase
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLintContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string // line:column: message prefix
+	}{
+		{
+			name: "lint issue",
+			content: `{
+  "queryName": "",
+  "httpMethod": "FETCH",
+  "apiName": "{host}/item/{id}",
+  "structure": [
+    {
+      "env": "staging",
+      "apiParamMap": {"host": "x", "id": 1}
+    },
+    {
+      "env": "Staging",
+      "apiParamMap": {"host": "x"}
+    },
+    {
+      "apiParamMap": []
+    }
+  ]
+}`,
+			want: []string{
+				"1:1: missing queryName",
+				`3:3: unknown httpMethod "FETCH", expected one of GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS`,
+				`11:7: duplicated env "Staging", already declared in structure[0]`,
+				`10:5: apiName placeholder {id} has no apiParamMap key in env "Staging"`,
+				"14:5: structure[2] missing env",
+				"15:7: apiParamMap is not an object",
+			},
+		},
+		{
+			name: "http method in any case",
+			content: `{"queryName": "a", "httpMethod": "Get", "apiName": "/x", "structure": [{"env": "staging"}]}
+`,
+		},
+		{
+			name: "invalid json syntax",
+			content: `{
+  "queryName": "a",
+  "structure": [
+    {"env": "staging",}
+  ]
+}`,
+			want: []string{"4:23: invalid json: invalid character '}'"},
+		},
+		{
+			name: "wrong type",
+			content: `{"queryName": "a", "httpMethod": "GET", "apiName": "/x",
+ "structure": [{"env": 1, "responseCode": "200"}]}`,
+			want: []string{
+				"2:17: invalid json: structure.0.env must be string, got number",
+				"2:27: invalid json: structure.0.responseCode must be int, got string",
+			},
+		},
+		{
+			name:    "empty structure",
+			content: "{\n  \"queryName\": \"a\",\n  \"query\": \"{ item }\",\n  \"structure\": []\n}",
+			want:    []string{"4:3: empty structure"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := LintContent("test.json", []byte(tt.content))
+			if len(issues) != len(tt.want) {
+				t.Fatalf("issues = %v, want %q", issues, tt.want)
+			}
+			for i, issue := range issues {
+				got := fmt.Sprintf("%d:%d: %s", issue.Line, issue.Column, issue.Message)
+				if !strings.HasPrefix(got, tt.want[i]) {
+					t.Errorf("issue %d = %q, want %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIndexLookup(t *testing.T) {
+	content := []byte(`{
+  "structure": [
+    {
+      "apiParamMap": {
+        "id": 1,
+        "a/b": {"c~": [2, 3]}
+      }
+    }
+  ]
+}`)
+	idx := NewIndex(content)
+	tests := []struct {
+		pointer string
+		want    Position
+	}{
+		{"", Position{1, 1}},
+		{"/structure", Position{2, 3}},
+		{"/structure/0", Position{3, 5}},
+		{"/structure/0/apiParamMap/id", Position{5, 9}},          // nested apiParamMap key
+		{"/structure/0/apiParamMap/a~1b/c~0/1", Position{6, 27}}, // escaped key and array element
+		{"/structure/0/apiParamMap/missing", Position{4, 7}},     // fallback to parent
+		{"/structure/1/env", Position{2, 3}},
+	}
+	for _, tt := range tests {
+		if got := idx.Lookup(content, tt.pointer); got != tt.want {
+			t.Errorf("Lookup(%q) = %v, want %v", tt.pointer, got, tt.want)
+		}
+	}
+}
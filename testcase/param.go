@@ -0,0 +1,42 @@
+package testcase
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RouteParams return apiParamMap without ignored keys (eg. host, consulHost)
+func (s Structure) RouteParams(ignoreKeys []string) (map[string]interface{}, error) {
+	params, err := s.Params()
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range ignoreKeys {
+		delete(params, key)
+	}
+	return params, nil
+}
+
+// FormatValue will format json value for url or sheet
+// number is printed without decimal, eg. 10 instead of 10.000000
+func FormatValue(data interface{}) string {
+	switch data.(type) {
+	case int:
+		return fmt.Sprintf("%d", data)
+	case float64:
+		return fmt.Sprintf("%.0f", data)
+	case string:
+		return fmt.Sprintf("%v", data)
+	default:
+		return fmt.Sprintf("%v", data)
+	}
+}
+
+// FillRoute will replace route param in apiName with its value
+// eg. /item/{id} with {"id": 10} -> /item/10
+func FillRoute(apiName string, params map[string]interface{}) string {
+	for key, data := range params {
+		apiName = strings.Replace(apiName, "{"+key+"}", FormatValue(data), -1)
+	}
+	return apiName
+}
@@ -0,0 +1,115 @@
+package testcase
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Position is line and column (both start from 1) in a json file
+type Position struct {
+	Line   int
+	Column int
+}
+
+// positionOf convert byte offset in content into line and column
+func positionOf(content []byte, offset int64) Position {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	before := content[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+	return Position{Line: line, Column: column}
+}
+
+// Index hold offset of every key and array element in a json file
+// keyed by json pointer, eg. /structure/0/env, key with / or ~ is escaped, see PointerToken
+// root object is keyed by empty string
+type Index map[string]int64
+
+// NewIndex will scan content and record offset of every value
+// invalid json will return partial index
+func NewIndex(content []byte) Index {
+	idx := make(Index)
+	dec := json.NewDecoder(bytes.NewReader(content))
+	idx.scan(dec, content, "")
+	return idx
+}
+
+// scan will read one value from dec and record its offset under path
+// for object, offset of each member is the offset of its key
+func (idx Index) scan(dec *json.Decoder, content []byte, path string) bool {
+	offset := skipSpace(content, dec.InputOffset())
+	if _, ok := idx[path]; !ok {
+		idx[path] = offset
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return false
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return true
+	}
+
+	switch delim {
+	case '{':
+		for dec.More() {
+			keyOffset := skipSpace(content, dec.InputOffset())
+			key, err := dec.Token()
+			if err != nil {
+				return false
+			}
+			child := path + "/" + PointerToken(key.(string))
+			idx[child] = keyOffset
+			if !idx.scan(dec, content, child) {
+				return false
+			}
+		}
+	case '[':
+		for i := 0; dec.More(); i++ {
+			if !idx.scan(dec, content, path+"/"+strconv.Itoa(i)) {
+				return false
+			}
+		}
+	}
+	_, err = dec.Token() // closing } or ]
+	return err == nil
+}
+
+// PointerToken will escape key as a json pointer token, eg. a/b -> a~1b
+func PointerToken(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// skipSpace will move offset to the start of next token
+// json.Decoder.InputOffset point right after previous token, before any whitespace, ":" or ","
+func skipSpace(content []byte, offset int64) int64 {
+	for offset < int64(len(content)) {
+		switch content[offset] {
+		case ' ', '\t', '\n', '\r', ':', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// Lookup return position of json pointer path
+// will fallback to the nearest parent that exist
+func (idx Index) Lookup(content []byte, path string) Position {
+	for {
+		if offset, ok := idx[path]; ok {
+			return positionOf(content, offset)
+		}
+		i := strings.LastIndexByte(path, '/')
+		if i < 0 {
+			return Position{Line: 1, Column: 1}
+		}
+		path = path[:i]
+	}
+}
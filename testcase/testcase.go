@@ -0,0 +1,112 @@
+// Package testcase parse integration test json, shared by every sweep tool
+package testcase
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// IntegrationTest is the content of one integration test json file
+// Query is only used by gql test, HttpMethod and ApiName by api and grpc test
+type IntegrationTest struct {
+	Path       string      `json:"-"` // file the test is loaded from
+	QueryName  string      `json:"queryName"`
+	Query      string      `json:"query,omitempty"`
+	HttpMethod string      `json:"httpMethod,omitempty"`
+	ApiName    string      `json:"apiName,omitempty"`
+	Structure  []Structure `json:"structure"`
+}
+
+// Structure hold value of integration test for one env
+// apiParamMap, variables and responseString are kept as raw json
+// so it can be written to sheet or postman as is
+type Structure struct {
+	Env            string          `json:"env"`
+	ResponseCode   int             `json:"responseCode"`
+	ApiParamMap    json.RawMessage `json:"apiParamMap,omitempty"`
+	Variables      json.RawMessage `json:"variables,omitempty"`
+	ResponseString json.RawMessage `json:"responseString,omitempty"`
+}
+
+// Load will read and unmarshal integration test from path
+func Load(path string) (*IntegrationTest, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	test := &IntegrationTest{Path: path}
+	if err := json.Unmarshal(content, test); err != nil {
+		return nil, &os.PathError{Op: "parse", Path: path, Err: err}
+	}
+	return test, nil
+}
+
+// WalkFunc is called by Walk for every integration test
+// err is not nil if the file can not be loaded, test will be nil
+type WalkFunc func(path string, test *IntegrationTest, err error) error
+
+// Walk will "walk" to every directory and subdirectory in root
+// and call fn for every json file in lexical order
+func Walk(root string, fn WalkFunc) error {
+	return WalkFiles(root, func(path string) error {
+		test, err := Load(path)
+		return fn(path, test, err)
+	})
+}
+
+// WalkFiles will call fn for every json file in root in lexical order
+func WalkFiles(root string, fn func(path string) error) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		return fn(path)
+	})
+}
+
+// FileName return base name of the file the test is loaded from
+func (t *IntegrationTest) FileName() string {
+	return filepath.Base(t.Path)
+}
+
+// Params will unmarshal apiParamMap
+// missing or null apiParamMap will return empty map
+func (s Structure) Params() (map[string]interface{}, error) {
+	return unmarshalObject(s.ApiParamMap)
+}
+
+// VariablesMap will unmarshal variables
+// missing or null variables will return empty map
+func (s Structure) VariablesMap() (map[string]interface{}, error) {
+	return unmarshalObject(s.Variables)
+}
+
+// VariablesString return variables as written in the json file
+// missing variables will return "{}"
+func (s Structure) VariablesString() string {
+	if len(s.Variables) == 0 {
+		return "{}"
+	}
+	return string(s.Variables)
+}
+
+// unmarshalObject will unmarshal raw json object into map
+func unmarshalObject(raw json.RawMessage) (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	if len(raw) == 0 {
+		return m, nil
+	}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	if m == nil { // null
+		m = make(map[string]interface{})
+	}
+	return m, nil
+}